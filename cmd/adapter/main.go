@@ -6,14 +6,15 @@ import (
 
 	"github.com/kedacore/keda/pkg/handler"
 	kedaprovider "github.com/kedacore/keda/pkg/provider"
+	"github.com/kedacore/keda/pkg/util"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
 	"k8s.io/klog/klogr"
 
 	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
-	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -59,15 +60,21 @@ func (a *Adapter) makeProviderOrDie() provider.MetricsProvider {
 		os.Exit(1)
 	}
 
-	handler := handler.NewScaleHandler(kubeclient, scheme)
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		logger.Error(err, "unable to construct new clientset")
+		os.Exit(1)
+	}
+
+	handler := handler.NewScaleHandler(kubeclient, clientset, scheme)
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	namespaces, err := util.GetWatchNamespaces()
 	if err != nil {
 		logger.Error(err, "failed to get watch namespace")
 		os.Exit(1)
 	}
 
-	return kedaprovider.NewProvider(logger, handler, kubeclient, namespace)
+	return kedaprovider.NewProvider(logger, handler, kubeclient, namespaces)
 }
 
 func main() {
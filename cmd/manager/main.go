@@ -13,6 +13,7 @@ import (
 
 	"github.com/kedacore/keda/pkg/apis"
 	"github.com/kedacore/keda/pkg/controller"
+	"github.com/kedacore/keda/pkg/util"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	kubemetrics "github.com/operator-framework/operator-sdk/pkg/kube-metrics"
@@ -24,6 +25,7 @@ import (
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -69,7 +71,7 @@ func main() {
 
 	printVersion()
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	namespaces, err := util.GetWatchNamespaces()
 	if err != nil {
 		log.Error(err, "Failed to get watch namespace")
 		os.Exit(1)
@@ -90,12 +92,29 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
-		Namespace:          namespace,
+	options := manager.Options{
 		MapperProvider:     restmapper.NewDynamicRESTMapper,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+	}
+	// Watch a single namespace, a list of namespaces or all namespaces (empty list)
+	namespace := ""
+	if len(namespaces) == 1 {
+		namespace = namespaces[0]
+		options.Namespace = namespace
+	} else if len(namespaces) > 1 {
+		log.Info("Watching multiple namespaces", "Namespaces", namespaces)
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+
+		// ServiceMonitor is created next to the metrics Service in the operator's namespace
+		namespace, err = k8sutil.GetOperatorNamespace()
+		if err != nil {
+			log.Error(err, "Failed to get operator namespace")
+			os.Exit(1)
+		}
+	}
+
+	// Create a new Cmd to provide shared dependencies and start components
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -23,6 +23,7 @@ const (
 // +kubebuilder:resource:path=scaledobjects,scope=Namespaced
 // +kubebuilder:printcolumn:name="Deployment",type="string",JSONPath=".spec.scaleTargetRef.deploymentName"
 // +kubebuilder:printcolumn:name="Triggers",type="string",JSONPath=".spec.triggers[*].type"
+// +kubebuilder:printcolumn:name="Last Decision",type="string",JSONPath=".status.scaleDecisions[-1:].reason"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type ScaledObject struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -47,13 +48,47 @@ type ScaledObjectSpec struct {
 	// +optional
 	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
 	// +optional
+	ActivationStabilizationWindow *int32 `json:"activationStabilizationWindow,omitempty"`
+	// +optional
+	DeactivationStabilizationWindow *int32 `json:"deactivationStabilizationWindow,omitempty"`
+	// +optional
 	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
 	// +optional
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+	// +optional
+	// +listType
+	ReplicaSchedules []ReplicaSchedule `json:"replicaSchedules,omitempty"`
+	// +optional
+	Advanced *AdvancedConfig `json:"advanced,omitempty"`
 	// +listType
 	Triggers []ScaleTriggers `json:"triggers"`
 }
 
+// AdvancedConfig holds the advanced settings of a ScaledObject
+// +k8s:openapi-gen=true
+type AdvancedConfig struct {
+	// RestoreToOriginalReplicaCount scales the Deployment back to the replica count it had
+	// before it was adopted by KEDA, when the ScaledObject is deleted.
+	// The replica count is recorded when KEDA creates the HPA, enabling it on an existing ScaledObject has no effect.
+	// +optional
+	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
+}
+
+// ReplicaSchedule overrides minReplicaCount and/or maxReplicaCount during a time window.
+// The window opens at every Start and closes at the following End, both are standard cron expressions
+// evaluated in Timezone (UTC if not set). If several windows are open, the first one in the list wins.
+// +k8s:openapi-gen=true
+type ReplicaSchedule struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+}
+
 // ObjectReference holds the a reference to the deployment this
 // ScaledObject applies
 // +k8s:openapi-gen=true
@@ -79,10 +114,50 @@ type ScaleTriggers struct {
 // +optional
 type ScaledObjectStatus struct {
 	// +optional
-	LastActiveTime  *metav1.Time `json:"lastActiveTime,omitempty"`
+	LastActiveTime *metav1.Time `json:"lastActiveTime,omitempty"`
 	// +optional
 	// +listType
 	ExternalMetricNames []string `json:"externalMetricNames,omitempty"`
+	// +optional
+	// +listType
+	ScaleDecisions []ScaleDecision `json:"scaleDecisions,omitempty"`
+}
+
+// ScaleDecisionReason describes why a scaling decision was made
+type ScaleDecisionReason string
+
+const (
+	// ScaleDecisionReasonActivation is used when the target was scaled up because a trigger became active
+	ScaleDecisionReasonActivation ScaleDecisionReason = "Activation"
+	// ScaleDecisionReasonDeactivation is used when the target was scaled to zero after the cooldown period
+	ScaleDecisionReasonDeactivation ScaleDecisionReason = "Deactivation"
+	// ScaleDecisionReasonCooldown is used when scaling to zero was postponed by the cooldown period
+	ScaleDecisionReasonCooldown ScaleDecisionReason = "Cooldown"
+	// ScaleDecisionReasonMinReplicaCount is used when the target was scaled up to minReplicaCount
+	ScaleDecisionReasonMinReplicaCount ScaleDecisionReason = "MinReplicaCount"
+)
+
+// ScaleDecision records a single scaling decision made by the scale loop
+// +k8s:openapi-gen=true
+type ScaleDecision struct {
+	Time                 metav1.Time         `json:"time"`
+	Reason               ScaleDecisionReason `json:"reason"`
+	PreviousReplicaCount int32               `json:"previousReplicaCount"`
+	NewReplicaCount      int32               `json:"newReplicaCount"`
+	// +optional
+	// +listType
+	Triggers []TriggerDecision `json:"triggers,omitempty"`
+}
+
+// TriggerDecision holds the state of a single trigger at the time of a ScaleDecision
+// +k8s:openapi-gen=true
+type TriggerDecision struct {
+	Type string `json:"type"`
+	// +optional
+	Name   string `json:"name,omitempty"`
+	Active bool   `json:"active"`
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,6 +9,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
+func (in *AdvancedConfig) DeepCopy() *AdvancedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdvancedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthEnvironment) DeepCopyInto(out *AuthEnvironment) {
 	*out = *in
@@ -73,6 +89,54 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSchedule) DeepCopyInto(out *ReplicaSchedule) {
+	*out = *in
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSchedule.
+func (in *ReplicaSchedule) DeepCopy() *ReplicaSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDecision) DeepCopyInto(out *ScaleDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerDecision, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDecision.
+func (in *ScaleDecision) DeepCopy() *ScaleDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 	*out = *in
@@ -201,6 +265,16 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ActivationStabilizationWindow != nil {
+		in, out := &in.ActivationStabilizationWindow, &out.ActivationStabilizationWindow
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeactivationStabilizationWindow != nil {
+		in, out := &in.DeactivationStabilizationWindow, &out.DeactivationStabilizationWindow
+		*out = new(int32)
+		**out = **in
+	}
 	if in.MinReplicaCount != nil {
 		in, out := &in.MinReplicaCount, &out.MinReplicaCount
 		*out = new(int32)
@@ -211,6 +285,18 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReplicaSchedules != nil {
+		in, out := &in.ReplicaSchedules, &out.ReplicaSchedules
+		*out = make([]ReplicaSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(AdvancedConfig)
+		**out = **in
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -243,6 +329,13 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ScaleDecisions != nil {
+		in, out := &in.ScaleDecisions, &out.ScaleDecisions
+		*out = make([]ScaleDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -342,3 +435,19 @@ func (in *TriggerAuthenticationSpec) DeepCopy() *TriggerAuthenticationSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerDecision) DeepCopyInto(out *TriggerDecision) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerDecision.
+func (in *TriggerDecision) DeepCopy() *TriggerDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerDecision)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -11,10 +11,13 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
+		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AdvancedConfig":            schema_pkg_apis_keda_v1alpha1_AdvancedConfig(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AuthEnvironment":           schema_pkg_apis_keda_v1alpha1_AuthEnvironment(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AuthPodIdentity":           schema_pkg_apis_keda_v1alpha1_AuthPodIdentity(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AuthSecretTargetRef":       schema_pkg_apis_keda_v1alpha1_AuthSecretTargetRef(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ObjectReference":           schema_pkg_apis_keda_v1alpha1_ObjectReference(ref),
+		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ReplicaSchedule":           schema_pkg_apis_keda_v1alpha1_ReplicaSchedule(ref),
+		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaleDecision":             schema_pkg_apis_keda_v1alpha1_ScaleDecision(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaleTriggers":             schema_pkg_apis_keda_v1alpha1_ScaleTriggers(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaledObject":              schema_pkg_apis_keda_v1alpha1_ScaledObject(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaledObjectAuthRef":       schema_pkg_apis_keda_v1alpha1_ScaledObjectAuthRef(ref),
@@ -22,6 +25,27 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaledObjectStatus":        schema_pkg_apis_keda_v1alpha1_ScaledObjectStatus(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.TriggerAuthentication":     schema_pkg_apis_keda_v1alpha1_TriggerAuthentication(ref),
 		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.TriggerAuthenticationSpec": schema_pkg_apis_keda_v1alpha1_TriggerAuthenticationSpec(ref),
+		"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.TriggerDecision":           schema_pkg_apis_keda_v1alpha1_TriggerDecision(ref),
+	}
+}
+
+func schema_pkg_apis_keda_v1alpha1_AdvancedConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AdvancedConfig holds the advanced settings of a ScaledObject",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"restoreToOriginalReplicaCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestoreToOriginalReplicaCount scales the Deployment back to the replica count it had before it was adopted by KEDA, when the ScaledObject is deleted. The replica count is recorded when KEDA creates the HPA, enabling it on an existing ScaledObject has no effect.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -135,6 +159,106 @@ func schema_pkg_apis_keda_v1alpha1_ObjectReference(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_keda_v1alpha1_ReplicaSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ReplicaSchedule overrides minReplicaCount and/or maxReplicaCount during a time window. The window opens at every Start and closes at the following End, both are standard cron expressions evaluated in Timezone (UTC if not set). If several windows are open, the first one in the list wins.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"timezone": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"minReplicaCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"maxReplicaCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+				Required: []string{"start", "end"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_keda_v1alpha1_ScaleDecision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScaleDecision records a single scaling decision made by the scale loop",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"previousReplicaCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"newReplicaCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"triggers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kedacore/keda/pkg/apis/keda/v1alpha1.TriggerDecision"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"time", "reason", "previousReplicaCount", "newReplicaCount"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.TriggerDecision", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_keda_v1alpha1_ScaleTriggers(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -282,6 +406,18 @@ func schema_pkg_apis_keda_v1alpha1_ScaledObjectSpec(ref common.ReferenceCallback
 							Format: "int32",
 						},
 					},
+					"activationStabilizationWindow": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"deactivationStabilizationWindow": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
 					"minReplicaCount": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"integer"},
@@ -294,6 +430,28 @@ func schema_pkg_apis_keda_v1alpha1_ScaledObjectSpec(ref common.ReferenceCallback
 							Format: "int32",
 						},
 					},
+					"replicaSchedules": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ReplicaSchedule"),
+									},
+								},
+							},
+						},
+					},
+					"advanced": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AdvancedConfig"),
+						},
+					},
 					"triggers": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -316,7 +474,7 @@ func schema_pkg_apis_keda_v1alpha1_ScaledObjectSpec(ref common.ReferenceCallback
 			},
 		},
 		Dependencies: []string{
-			"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ObjectReference", "github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaleTriggers", "k8s.io/api/batch/v1.JobSpec"},
+			"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AdvancedConfig", "github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ObjectReference", "github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ReplicaSchedule", "github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaleTriggers", "k8s.io/api/batch/v1.JobSpec"},
 	}
 }
 
@@ -350,11 +508,28 @@ func schema_pkg_apis_keda_v1alpha1_ScaledObjectStatus(ref common.ReferenceCallba
 							},
 						},
 					},
+					"scaleDecisions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaleDecision"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.ScaleDecision", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -451,3 +626,41 @@ func schema_pkg_apis_keda_v1alpha1_TriggerAuthenticationSpec(ref common.Referenc
 			"github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AuthEnvironment", "github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AuthPodIdentity", "github.com/kedacore/keda/pkg/apis/keda/v1alpha1.AuthSecretTargetRef"},
 	}
 }
+
+func schema_pkg_apis_keda_v1alpha1_TriggerDecision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TriggerDecision holds the state of a single trigger at the time of a ScaleDecision",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"active": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"type", "active"},
+			},
+		},
+	}
+}
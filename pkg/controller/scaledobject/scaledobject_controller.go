@@ -3,19 +3,23 @@ package scaledobject
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
 	scalehandler "github.com/kedacore/keda/pkg/handler"
 	version "github.com/kedacore/keda/version"
 
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -39,12 +43,16 @@ var log = logf.Log.WithName("controller_scaledobject")
 // Add creates a new ScaledObject Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, clientset))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileScaledObject{client: mgr.GetClient(), scheme: mgr.GetScheme(), scaleLoopContexts: &sync.Map{}, scaledObjectsGenerations: &sync.Map{}}
+func newReconciler(mgr manager.Manager, clientset kubernetes.Interface) reconcile.Reconciler {
+	return &ReconcileScaledObject{client: mgr.GetClient(), clientset: clientset, scheme: mgr.GetScheme(), scaleLoopContexts: &sync.Map{}, scaledObjectsGenerations: &sync.Map{}}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -88,6 +96,7 @@ type ReconcileScaledObject struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	client                   client.Client
+	clientset                kubernetes.Interface
 	scheme                   *runtime.Scheme
 	scaleLoopContexts        *sync.Map
 	scaledObjectsGenerations *sync.Map
@@ -223,6 +232,17 @@ func (r *ReconcileScaledObject) reconcileDeploymentType(logger logr.Logger, scal
 		return reconcile.Result{}, err
 	}
 
+	// HPA bounds can be overridden by ReplicaSchedules, requeue at the next window boundary to update them
+	_, _, nextBoundary, err := scalehandler.GetReplicaCountBounds(scaledObject, time.Now())
+	if err != nil {
+		logger.Error(err, "Notified about ScaledObject with incorrect replicaSchedules specification")
+		return reconcile.Result{}, err
+	}
+	result := reconcile.Result{}
+	if !nextBoundary.IsZero() {
+		result.RequeueAfter = time.Until(nextBoundary) + time.Second
+	}
+
 	hpaName := getHpaName(deploymentName)
 	hpaNamespace := scaledObject.Namespace
 
@@ -230,6 +250,14 @@ func (r *ReconcileScaledObject) reconcileDeploymentType(logger logr.Logger, scal
 	foundHpa := &autoscalingv2beta1.HorizontalPodAutoscaler{}
 	err = r.client.Get(context.TODO(), types.NamespacedName{Name: hpaName, Namespace: hpaNamespace}, foundHpa)
 	if err != nil && errors.IsNotFound(err) {
+		// record the Deployment's replica count before KEDA starts scaling it, if it should be restored later.
+		// Once the HPA exists the replica count is KEDA's, so it is only recorded when the HPA is created.
+		err = r.recordOriginalReplicaCount(logger, scaledObject, deploymentName)
+		if err != nil {
+			logger.Error(err, "Failed to record original replica count of the Deployment")
+			return reconcile.Result{}, err
+		}
+
 		logger.Info("Creating a new HPA", "HPA.Namespace", hpaNamespace, "HPA.Name", hpaName)
 		hpa, err := r.newHPAForScaledObject(logger, scaledObject)
 		if err != nil {
@@ -255,8 +283,8 @@ func (r *ReconcileScaledObject) reconcileDeploymentType(logger logr.Logger, scal
 			return reconcile.Result{}, err
 		}
 
-		// HPA created successfully & ScaleLoop started - don't requeue
-		return reconcile.Result{}, nil
+		// HPA created successfully & ScaleLoop started - requeue only for ReplicaSchedules
+		return result, nil
 	} else if err != nil {
 		logger.Error(err, "Failed to get HPA")
 		return reconcile.Result{}, err
@@ -291,7 +319,7 @@ func (r *ReconcileScaledObject) reconcileDeploymentType(logger logr.Logger, scal
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return result, nil
 }
 
 func checkDeploymentTypeScaledObject(scaledObject *kedav1alpha1.ScaledObject) (string, error) {
@@ -323,12 +351,47 @@ func (r *ReconcileScaledObject) checkScaledObjectLabel(logger logr.Logger, scale
 	return r.client.Update(context.TODO(), scaledObject)
 }
 
+// recordOriginalReplicaCount stores the Deployment's replica count in an annotation when the Deployment is adopted,
+// so it can be restored when the ScaledObject is deleted. An existing annotation is never overwritten.
+func (r *ReconcileScaledObject) recordOriginalReplicaCount(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, deploymentName string) error {
+	if scaledObject.Spec.Advanced == nil || !scaledObject.Spec.Advanced.RestoreToOriginalReplicaCount {
+		return nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: deploymentName, Namespace: scaledObject.Namespace}, deployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Deployment not found, original replica count will be recorded later", "Deployment.Name", deploymentName)
+			return nil
+		}
+		return err
+	}
+
+	if _, found := deployment.Annotations[originalReplicaCountAnnotation]; found {
+		return nil
+	}
+
+	// Kubernetes defaults to 1 replica if it is not specified
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[originalReplicaCountAnnotation] = strconv.Itoa(int(replicas))
+
+	logger.Info("Recording original replica count of the Deployment", "Deployment.Name", deploymentName, "Deployment.Replicas", replicas)
+	return r.client.Update(context.TODO(), deployment)
+}
+
 // startScaleLoop starts ScaleLoop handler for the respective ScaledObject
 func (r *ReconcileScaledObject) startScaleLoop(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
 
 	logger.V(1).Info("Starting a new ScaleLoop")
 
-	scaleHandler := scalehandler.NewScaleHandler(r.client, r.scheme)
+	scaleHandler := scalehandler.NewScaleHandler(r.client, r.clientset, r.scheme)
 
 	key, err := cache.MetaNamespaceKeyFunc(scaledObject)
 	if err != nil {
@@ -395,10 +458,15 @@ func (r *ReconcileScaledObject) newHPAForScaledObject(logger logr.Logger, scaled
 		return nil, err
 	}
 
+	minReplicas, maxReplicas, err := getHpaReplicaBounds(logger, scaledObject, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	return &autoscalingv2beta1.HorizontalPodAutoscaler{
 		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
-			MinReplicas: getHpaMinReplicas(scaledObject),
-			MaxReplicas: getHpaMaxReplicas(scaledObject),
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
 			Metrics:     scaledObjectMetricSpecs,
 			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
 				Name:       deploymentName,
@@ -419,13 +487,17 @@ func (r *ReconcileScaledObject) newHPAForScaledObject(logger logr.Logger, scaled
 // checkHPAForUpdate checks whether update of HPA is needed
 func (r *ReconcileScaledObject) checkHPAForUpdate(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, foundHpa *autoscalingv2beta1.HorizontalPodAutoscaler, deploymentName string) (bool, error) {
 	updateHPA := false
-	scaledObjectMinReplicaCount := getHpaMinReplicas(scaledObject)
+	scaledObjectMinReplicaCount, scaledObjectMaxReplicaCount, err := getHpaReplicaBounds(logger, scaledObject, time.Now())
+	if err != nil {
+		logger.Error(err, "Failed to get the replica count bounds")
+		return true, err
+	}
+
 	if *foundHpa.Spec.MinReplicas != *scaledObjectMinReplicaCount {
 		updateHPA = true
 		foundHpa.Spec.MinReplicas = scaledObjectMinReplicaCount
 	}
 
-	scaledObjectMaxReplicaCount := getHpaMaxReplicas(scaledObject)
 	if foundHpa.Spec.MaxReplicas != scaledObjectMaxReplicaCount {
 		updateHPA = true
 		foundHpa.Spec.MaxReplicas = scaledObjectMaxReplicaCount
@@ -449,7 +521,7 @@ func (r *ReconcileScaledObject) getScaledObjectMetricSpecs(logger logr.Logger, s
 	var scaledObjectMetricSpecs []autoscalingv2beta1.MetricSpec
 	var externalMetricNames []string
 
-	scalers, _, err := scalehandler.NewScaleHandler(r.client, r.scheme).GetDeploymentScalers(scaledObject)
+	scalers, _, err := scalehandler.NewScaleHandler(r.client, r.clientset, r.scheme).GetDeploymentScalers(scaledObject)
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
 		return nil, err
@@ -484,19 +556,29 @@ func getHpaName(deploymentName string) string {
 	return fmt.Sprintf("keda-hpa-%s", deploymentName)
 }
 
-// getHpaMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
-func getHpaMinReplicas(scaledObject *kedav1alpha1.ScaledObject) *int32 {
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		return scaledObject.Spec.MinReplicaCount
+// getHpaReplicaBounds returns MinReplicas and MaxReplicas based on definition in ScaledObject (including the ReplicaSchedule
+// open at the given time) or default values if not defined.
+// A MinReplicas above MaxReplicas, eg. when a ReplicaSchedule only raises minReplicaCount, is lowered to MaxReplicas
+// as the HPA would be rejected otherwise.
+func getHpaReplicaBounds(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, now time.Time) (*int32, int32, error) {
+	minReplicaCount, maxReplicaCount, _, err := scalehandler.GetReplicaCountBounds(scaledObject, now)
+	if err != nil {
+		return nil, 0, err
 	}
-	tmp := defaultHPAMinReplicas
-	return &tmp
-}
 
-// getHpaMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
-func getHpaMaxReplicas(scaledObject *kedav1alpha1.ScaledObject) int32 {
-	if scaledObject.Spec.MaxReplicaCount != nil {
-		return *scaledObject.Spec.MaxReplicaCount
+	minReplicas := defaultHPAMinReplicas
+	if minReplicaCount != nil && *minReplicaCount > 0 {
+		minReplicas = *minReplicaCount
+	}
+	maxReplicas := defaultHPAMaxReplicas
+	if maxReplicaCount != nil {
+		maxReplicas = *maxReplicaCount
 	}
-	return defaultHPAMaxReplicas
+
+	if minReplicas > maxReplicas {
+		logger.Info("minReplicaCount is greater than maxReplicaCount, using maxReplicaCount as HPA MinReplicas", "MinReplicaCount", minReplicas, "MaxReplicaCount", maxReplicas)
+		minReplicas = maxReplicas
+	}
+
+	return &minReplicas, maxReplicas, nil
 }
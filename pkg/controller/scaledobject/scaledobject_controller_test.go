@@ -0,0 +1,180 @@
+package scaledobject
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type hpaReplicaBoundsTestData struct {
+	comment     string
+	spec        kedav1alpha1.ScaledObjectSpec
+	isError     bool
+	expectedMin int32
+	expectedMax int32
+}
+
+var (
+	hpaSpecMaxReplicaCount      int32 = 5
+	hpaScheduleMinReplicaCount  int32 = 10
+	hpaScheduleMaxReplicaCount  int32 = 20
+	hpaMondayMorning                  = time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC)
+	hpaReplicaBoundsTestDataset       = []hpaReplicaBoundsTestData{
+		{"defaults", kedav1alpha1.ScaledObjectSpec{}, false, defaultHPAMinReplicas, defaultHPAMaxReplicas},
+		{"schedule raises min and max",
+			kedav1alpha1.ScaledObjectSpec{
+				MaxReplicaCount:  &hpaSpecMaxReplicaCount,
+				ReplicaSchedules: []kedav1alpha1.ReplicaSchedule{{Start: "0 9 * * 1-5", End: "0 17 * * 1-5", MinReplicaCount: &hpaScheduleMinReplicaCount, MaxReplicaCount: &hpaScheduleMaxReplicaCount}},
+			}, false, hpaScheduleMinReplicaCount, hpaScheduleMaxReplicaCount},
+		{"schedule raises min above the max of the spec",
+			kedav1alpha1.ScaledObjectSpec{
+				MaxReplicaCount:  &hpaSpecMaxReplicaCount,
+				ReplicaSchedules: []kedav1alpha1.ReplicaSchedule{{Start: "0 9 * * 1-5", End: "0 17 * * 1-5", MinReplicaCount: &hpaScheduleMinReplicaCount}},
+			}, false, hpaSpecMaxReplicaCount, hpaSpecMaxReplicaCount},
+		{"invalid schedule",
+			kedav1alpha1.ScaledObjectSpec{
+				ReplicaSchedules: []kedav1alpha1.ReplicaSchedule{{Start: "0 9 * *", End: "0 17 * * *", MinReplicaCount: &hpaScheduleMinReplicaCount}},
+			}, true, 0, 0},
+	}
+)
+
+func TestGetHpaReplicaBounds(t *testing.T) {
+	for _, testData := range hpaReplicaBoundsTestDataset {
+		scaledObject := &kedav1alpha1.ScaledObject{Spec: testData.spec}
+
+		minReplicas, maxReplicas, err := getHpaReplicaBounds(logf.Log, scaledObject, hpaMondayMorning)
+		if testData.isError {
+			if err == nil {
+				t.Errorf("%s: expected error but got success", testData.comment)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+			continue
+		}
+		if *minReplicas != testData.expectedMin || maxReplicas != testData.expectedMax {
+			t.Errorf("%s: expected bounds %d-%d but got %d-%d", testData.comment, testData.expectedMin, testData.expectedMax, *minReplicas, maxReplicas)
+		}
+	}
+}
+
+const testDeploymentName = "keda-test"
+
+func newTestReconciler(objs ...runtime.Object) *ReconcileScaledObject {
+	return &ReconcileScaledObject{client: fake.NewFakeClient(objs...), scaleLoopContexts: &sync.Map{}, scaledObjectsGenerations: &sync.Map{}}
+}
+
+func newTestDeployment(replicas int32, annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testDeploymentName, Namespace: "default", Annotations: annotations},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+}
+
+func newTestScaledObject(restoreToOriginalReplicaCount bool) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "keda-test", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ObjectReference{DeploymentName: testDeploymentName},
+			Advanced:       &kedav1alpha1.AdvancedConfig{RestoreToOriginalReplicaCount: restoreToOriginalReplicaCount},
+		},
+	}
+}
+
+func getTestDeployment(t *testing.T, r *ReconcileScaledObject) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: testDeploymentName, Namespace: "default"}, deployment); err != nil {
+		t.Fatal("Expected the Deployment but got error", err)
+	}
+	return deployment
+}
+
+func TestRecordOriginalReplicaCount(t *testing.T) {
+	r := newTestReconciler(newTestDeployment(3, nil))
+
+	if err := r.recordOriginalReplicaCount(logf.Log, newTestScaledObject(true), testDeploymentName); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	deployment := getTestDeployment(t, r)
+	if value := deployment.Annotations[originalReplicaCountAnnotation]; value != "3" {
+		t.Errorf("Expected the original replica count 3 to be recorded but got %q", value)
+	}
+
+	// KEDA scales the Deployment, the recorded count must stay the original one
+	replicas := int32(7)
+	deployment.Spec.Replicas = &replicas
+	if err := r.client.Update(context.TODO(), deployment); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if err := r.recordOriginalReplicaCount(logf.Log, newTestScaledObject(true), testDeploymentName); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if value := getTestDeployment(t, r).Annotations[originalReplicaCountAnnotation]; value != "3" {
+		t.Errorf("Expected the original replica count 3 to be kept but got %q", value)
+	}
+}
+
+func TestRecordOriginalReplicaCountDisabled(t *testing.T) {
+	r := newTestReconciler(newTestDeployment(3, nil))
+
+	if err := r.recordOriginalReplicaCount(logf.Log, newTestScaledObject(false), testDeploymentName); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, found := getTestDeployment(t, r).Annotations[originalReplicaCountAnnotation]; found {
+		t.Error("Expected no original replica count to be recorded")
+	}
+}
+
+type restoreOriginalReplicaCountTestData struct {
+	comment                       string
+	restoreToOriginalReplicaCount bool
+	annotation                    string
+	expectedReplicas              int32
+	expectHpaDeleted              bool
+}
+
+var restoreOriginalReplicaCountTestDataset = []restoreOriginalReplicaCountTestData{
+	{"restore", true, "3", 3, true},
+	{"invalid annotation", true, "three", 10, false},
+	{"option off", false, "3", 10, false},
+}
+
+func TestRestoreOriginalReplicaCount(t *testing.T) {
+	for _, testData := range restoreOriginalReplicaCountTestDataset {
+		hpa := &autoscalingv2beta1.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: getHpaName(testDeploymentName), Namespace: "default"},
+		}
+		deployment := newTestDeployment(10, map[string]string{originalReplicaCountAnnotation: testData.annotation})
+		r := newTestReconciler(deployment, hpa)
+
+		if err := r.finalizeScaledObject(logf.Log, newTestScaledObject(testData.restoreToOriginalReplicaCount)); err != nil {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+			continue
+		}
+
+		deployment = getTestDeployment(t, r)
+		if *deployment.Spec.Replicas != testData.expectedReplicas {
+			t.Errorf("%s: expected %d replicas but got %d", testData.comment, testData.expectedReplicas, *deployment.Spec.Replicas)
+		}
+		if _, found := deployment.Annotations[originalReplicaCountAnnotation]; found {
+			t.Errorf("%s: expected the annotation to be removed", testData.comment)
+		}
+
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: hpa.Name, Namespace: hpa.Namespace}, &autoscalingv2beta1.HorizontalPodAutoscaler{})
+		if hpaDeleted := errors.IsNotFound(err); hpaDeleted != testData.expectHpaDeleted {
+			t.Errorf("%s: expected the HPA to be deleted %t but got %t", testData.comment, testData.expectHpaDeleted, hpaDeleted)
+		}
+	}
+}
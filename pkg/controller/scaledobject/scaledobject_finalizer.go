@@ -2,8 +2,14 @@ package scaledobject
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
 	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
@@ -11,6 +17,8 @@ import (
 
 const (
 	scaledObjectFinalizer = "finalizer.keda.k8s.io"
+	// Annotation on the Deployment holding its replica count before it was adopted by KEDA
+	originalReplicaCountAnnotation = "keda.k8s.io/original-replica-count"
 )
 
 // finalizeScaledObject is stopping ScaleLoop for the respective ScaleObject
@@ -35,10 +43,61 @@ func (r *ReconcileScaledObject) finalizeScaledObject(logger logr.Logger, scaledO
 		logger.V(1).Info("ScaleObject was not found in controller cache", "key", key)
 	}
 
+	if scaledObject.Spec.ScaleTargetRef != nil && scaledObject.Spec.ScaleTargetRef.DeploymentName != "" {
+		if err := r.restoreOriginalReplicaCount(logger, scaledObject); err != nil {
+			logger.Error(err, "Failed to restore original replica count of the Deployment")
+			return err
+		}
+	}
+
 	logger.Info("Successfully finalized ScaledObject")
 	return nil
 }
 
+// restoreOriginalReplicaCount scales the Deployment back to the replica count recorded when it was adopted
+// and removes the annotation. The HPA is deleted first, so it doesn't scale the Deployment again.
+func (r *ReconcileScaledObject) restoreOriginalReplicaCount(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	deploymentName := scaledObject.Spec.ScaleTargetRef.DeploymentName
+	deployment := &appsv1.Deployment{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: deploymentName, Namespace: scaledObject.Namespace}, deployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	value, found := deployment.Annotations[originalReplicaCountAnnotation]
+	if !found {
+		return nil
+	}
+	delete(deployment.Annotations, originalReplicaCountAnnotation)
+
+	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.RestoreToOriginalReplicaCount {
+		replicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			logger.Error(err, "Invalid original replica count on the Deployment, not restoring it", "Deployment.Name", deploymentName, "Annotation", value)
+		} else {
+			hpa := &autoscalingv2beta1.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      getHpaName(deploymentName),
+					Namespace: scaledObject.Namespace,
+				},
+			}
+			err = r.client.Delete(context.TODO(), hpa)
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+
+			originalReplicas := int32(replicas)
+			deployment.Spec.Replicas = &originalReplicas
+			logger.Info("Restoring original replica count of the Deployment", "Deployment.Name", deploymentName, "Deployment.Replicas", originalReplicas)
+		}
+	}
+
+	return r.client.Update(context.TODO(), deployment)
+}
+
 // addFinalizer adds finalizer to the ScaledObject
 func (r *ReconcileScaledObject) addFinalizer(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
 	logger.Info("Adding Finalizer for the ScaledObject")
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+
+	"github.com/robfig/cron/v3"
+)
+
+// GetReplicaCountBounds returns minReplicaCount and maxReplicaCount in effect at the given time.
+// The first ReplicaSchedule whose window is open overrides the values from the ScaledObject's Spec.
+// It also returns the time of the next window boundary, so callers know when the bounds can change,
+// the returned time is zero if the ScaledObject has no ReplicaSchedules.
+func GetReplicaCountBounds(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (*int32, *int32, time.Time, error) {
+	minReplicaCount := scaledObject.Spec.MinReplicaCount
+	maxReplicaCount := scaledObject.Spec.MaxReplicaCount
+	var nextBoundary time.Time
+	matched := false
+
+	for i, schedule := range scaledObject.Spec.ReplicaSchedules {
+		isOpen, boundary, err := getReplicaScheduleState(schedule, now)
+		if err != nil {
+			return scaledObject.Spec.MinReplicaCount, scaledObject.Spec.MaxReplicaCount, time.Time{}, fmt.Errorf("error parsing replicaSchedules[%d]: %s", i, err)
+		}
+
+		if nextBoundary.IsZero() || boundary.Before(nextBoundary) {
+			nextBoundary = boundary
+		}
+
+		if isOpen && !matched {
+			matched = true
+			if schedule.MinReplicaCount != nil {
+				minReplicaCount = schedule.MinReplicaCount
+			}
+			if schedule.MaxReplicaCount != nil {
+				maxReplicaCount = schedule.MaxReplicaCount
+			}
+		}
+	}
+
+	return minReplicaCount, maxReplicaCount, nextBoundary, nil
+}
+
+// getReplicaScheduleState returns whether the schedule's window is open at the given time
+// and when it opens or closes next
+func getReplicaScheduleState(schedule kedav1alpha1.ReplicaSchedule, now time.Time) (bool, time.Time, error) {
+	location := time.UTC
+	if schedule.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid timezone %s: %s", schedule.Timezone, err)
+		}
+	}
+
+	start, err := cron.ParseStandard(schedule.Start)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid start %s: %s", schedule.Start, err)
+	}
+	end, err := cron.ParseStandard(schedule.End)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid end %s: %s", schedule.End, err)
+	}
+
+	localNow := now.In(location)
+	nextStart := start.Next(localNow)
+	nextEnd := end.Next(localNow)
+
+	// the window is open if it closes before it opens again
+	if nextEnd.Before(nextStart) {
+		return true, nextEnd, nil
+	}
+	return false, nextStart, nil
+}
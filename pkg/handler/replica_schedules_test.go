@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+)
+
+type replicaSchedulesTestData struct {
+	comment              string
+	now                  time.Time
+	schedules            []kedav1alpha1.ReplicaSchedule
+	isError              bool
+	expectedMin          int32
+	expectedMax          int32
+	expectedNextBoundary time.Time
+}
+
+var (
+	specMinReplicaCount        int32 = 0
+	specMaxReplicaCount        int32 = 20
+	tradingMinReplicaCount     int32 = 10
+	weekendMaxReplicaCount     int32 = 2
+	overlapMinReplicaCount     int32 = 5
+	tradingHoursSchedule             = kedav1alpha1.ReplicaSchedule{Start: "0 9 * * 1-5", End: "0 17 * * 1-5", Timezone: "America/New_York", MinReplicaCount: &tradingMinReplicaCount}
+	weekendSchedule                  = kedav1alpha1.ReplicaSchedule{Start: "0 0 * * 6", End: "0 0 * * 1", MaxReplicaCount: &weekendMaxReplicaCount}
+	overlappingTradingSchedule       = kedav1alpha1.ReplicaSchedule{Start: "0 8 * * *", End: "0 18 * * *", Timezone: "America/New_York", MinReplicaCount: &overlapMinReplicaCount}
+)
+
+var replicaSchedulesTestDataset = []replicaSchedulesTestData{
+	// no schedules
+	{"no schedules", time.Date(2020, 1, 6, 15, 0, 0, 0, time.UTC), nil, false, specMinReplicaCount, specMaxReplicaCount, time.Time{}},
+	// Monday 10:00 in New York, trading hours window is open
+	{"window open", time.Date(2020, 1, 6, 15, 0, 0, 0, time.UTC), []kedav1alpha1.ReplicaSchedule{tradingHoursSchedule}, false, tradingMinReplicaCount, specMaxReplicaCount, time.Date(2020, 1, 6, 22, 0, 0, 0, time.UTC)},
+	// Monday 18:00 in New York, trading hours window is closed
+	{"window closed", time.Date(2020, 1, 6, 23, 0, 0, 0, time.UTC), []kedav1alpha1.ReplicaSchedule{tradingHoursSchedule}, false, specMinReplicaCount, specMaxReplicaCount, time.Date(2020, 1, 7, 14, 0, 0, 0, time.UTC)},
+	// Saturday, only the weekend window is open, the next boundary is the end of the weekend window
+	{"weekend", time.Date(2020, 1, 11, 12, 0, 0, 0, time.UTC), []kedav1alpha1.ReplicaSchedule{tradingHoursSchedule, weekendSchedule}, false, specMinReplicaCount, weekendMaxReplicaCount, time.Date(2020, 1, 13, 0, 0, 0, 0, time.UTC)},
+	// both windows are open, the first one wins
+	{"first window wins", time.Date(2020, 1, 6, 15, 0, 0, 0, time.UTC), []kedav1alpha1.ReplicaSchedule{tradingHoursSchedule, overlappingTradingSchedule}, false, tradingMinReplicaCount, specMaxReplicaCount, time.Date(2020, 1, 6, 22, 0, 0, 0, time.UTC)},
+	// invalid cron expression
+	{"invalid start", time.Date(2020, 1, 6, 15, 0, 0, 0, time.UTC), []kedav1alpha1.ReplicaSchedule{{Start: "0 9 * *", End: "0 17 * * *"}}, true, specMinReplicaCount, specMaxReplicaCount, time.Time{}},
+	// invalid timezone
+	{"invalid timezone", time.Date(2020, 1, 6, 15, 0, 0, 0, time.UTC), []kedav1alpha1.ReplicaSchedule{{Start: "0 9 * * *", End: "0 17 * * *", Timezone: "Mars/Olympus_Mons"}}, true, specMinReplicaCount, specMaxReplicaCount, time.Time{}},
+}
+
+func TestGetReplicaCountBounds(t *testing.T) {
+	for _, testData := range replicaSchedulesTestDataset {
+		scaledObject := &kedav1alpha1.ScaledObject{
+			Spec: kedav1alpha1.ScaledObjectSpec{
+				MinReplicaCount:  &specMinReplicaCount,
+				MaxReplicaCount:  &specMaxReplicaCount,
+				ReplicaSchedules: testData.schedules,
+			},
+		}
+
+		minReplicaCount, maxReplicaCount, nextBoundary, err := GetReplicaCountBounds(scaledObject, testData.now)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.comment)
+		}
+		if *minReplicaCount != testData.expectedMin {
+			t.Errorf("%s: expected minReplicaCount %d but got %d", testData.comment, testData.expectedMin, *minReplicaCount)
+		}
+		if *maxReplicaCount != testData.expectedMax {
+			t.Errorf("%s: expected maxReplicaCount %d but got %d", testData.comment, testData.expectedMax, *maxReplicaCount)
+		}
+		if !nextBoundary.Equal(testData.expectedNextBoundary) {
+			t.Errorf("%s: expected next boundary %s but got %s", testData.comment, testData.expectedNextBoundary, nextBoundary)
+		}
+	}
+}
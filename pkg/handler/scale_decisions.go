@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/pkg/scalers"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Number of ScaleDecisions kept in the ScaledObject's Status
+	maxScaleDecisionHistory = 10
+)
+
+// triggerDecisionsFunc returns the state of the triggers for a ScaleDecision.
+// It is only called when a decision is actually recorded, as getting the metric values queries the scalers again.
+type triggerDecisionsFunc func() []kedav1alpha1.TriggerDecision
+
+// recordScaleDecision appends a new ScaleDecision to the ScaledObject's Status, dropping the oldest
+// entries so that at most maxScaleDecisionHistory are kept.
+// A decision identical to the last recorded one (same reason and replica counts) is not recorded again,
+// so a ScaledObject that stays in cooldown doesn't flush the history nor query the scalers for their values.
+// Returns true if the Status was modified and should be updated.
+func recordScaleDecision(scaledObject *kedav1alpha1.ScaledObject, reason kedav1alpha1.ScaleDecisionReason, previousReplicaCount, newReplicaCount int32, getTriggers triggerDecisionsFunc) bool {
+	decisions := scaledObject.Status.ScaleDecisions
+	if len(decisions) > 0 {
+		last := decisions[len(decisions)-1]
+		if last.Reason == reason && last.PreviousReplicaCount == previousReplicaCount && last.NewReplicaCount == newReplicaCount {
+			return false
+		}
+	}
+
+	var triggers []kedav1alpha1.TriggerDecision
+	if getTriggers != nil {
+		triggers = getTriggers()
+	}
+
+	decisions = append(decisions, kedav1alpha1.ScaleDecision{
+		Time:                 metav1.Now(),
+		Reason:               reason,
+		PreviousReplicaCount: previousReplicaCount,
+		NewReplicaCount:      newReplicaCount,
+		Triggers:             triggers,
+	})
+	if len(decisions) > maxScaleDecisionHistory {
+		decisions = decisions[len(decisions)-maxScaleDecisionHistory:]
+	}
+	scaledObject.Status.ScaleDecisions = decisions
+
+	return true
+}
+
+// getTriggerDecisions returns the triggerDecisionsFunc completing the given trigger states with the current value
+// of the first metric provided by their scaler (summed up if the scaler reports it in several parts, as the HPA does).
+// Failing to get a value is not fatal, the decision is recorded without it.
+func (h *ScaleHandler) getTriggerDecisions(ctx context.Context, triggers []kedav1alpha1.TriggerDecision, triggerScalers []scalers.Scaler) triggerDecisionsFunc {
+	return func() []kedav1alpha1.TriggerDecision {
+		decisions := make([]kedav1alpha1.TriggerDecision, len(triggers))
+		copy(decisions, triggers)
+		for i := range decisions {
+			decisions[i].Value = h.getTriggerValue(ctx, decisions[i].Type, triggerScalers[i])
+		}
+		return decisions
+	}
+}
+
+func (h *ScaleHandler) getTriggerValue(ctx context.Context, triggerType string, scaler scalers.Scaler) string {
+	for _, metricSpec := range scaler.GetMetricSpecForScaling() {
+		if metricSpec.External == nil {
+			continue
+		}
+		metrics, err := scaler.GetMetrics(ctx, metricSpec.External.MetricName, nil)
+		if err != nil {
+			h.logger.V(1).Info("Error getting metric value for scale decision", "Trigger.Type", triggerType, "Error", err)
+		} else if len(metrics) > 0 {
+			value := metrics[0].Value.DeepCopy()
+			for _, metric := range metrics[1:] {
+				value.Add(metric.Value)
+			}
+			return value.String()
+		}
+		break
+	}
+
+	return ""
+}
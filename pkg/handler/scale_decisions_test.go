@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"testing"
+
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+)
+
+func TestRecordScaleDecisionKeepsLastDecisions(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+
+	for i := int32(0); i < maxScaleDecisionHistory+5; i++ {
+		if !recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonActivation, i, i+1, nil) {
+			t.Errorf("Expected decision #%d to be recorded", i)
+		}
+	}
+
+	decisions := scaledObject.Status.ScaleDecisions
+	if len(decisions) != maxScaleDecisionHistory {
+		t.Fatalf("Expected %d decisions but got %d", maxScaleDecisionHistory, len(decisions))
+	}
+	if decisions[0].PreviousReplicaCount != 5 {
+		t.Errorf("Expected oldest decision to start from 5 replicas but got %d", decisions[0].PreviousReplicaCount)
+	}
+	if decisions[len(decisions)-1].NewReplicaCount != maxScaleDecisionHistory+5 {
+		t.Errorf("Expected newest decision to end with %d replicas but got %d", maxScaleDecisionHistory+5, decisions[len(decisions)-1].NewReplicaCount)
+	}
+}
+
+func TestRecordScaleDecisionSkipsRepeatedDecision(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	calls := 0
+	getTriggers := func() []kedav1alpha1.TriggerDecision {
+		calls++
+		return []kedav1alpha1.TriggerDecision{{Type: "redis", Active: false, Value: "0"}}
+	}
+
+	if !recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonCooldown, 2, 2, getTriggers) {
+		t.Error("Expected first cooldown decision to be recorded")
+	}
+	if recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonCooldown, 2, 2, getTriggers) {
+		t.Error("Expected repeated cooldown decision to be skipped")
+	}
+	if !recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonDeactivation, 2, 0, getTriggers) {
+		t.Error("Expected deactivation decision to be recorded")
+	}
+
+	if len(scaledObject.Status.ScaleDecisions) != 2 {
+		t.Errorf("Expected 2 decisions but got %d", len(scaledObject.Status.ScaleDecisions))
+	}
+	// the scalers are not queried for a decision that isn't recorded
+	if calls != 2 {
+		t.Errorf("Expected the triggers to be read for the 2 recorded decisions but they were read %d times", calls)
+	}
+	if scaledObject.Status.ScaleDecisions[1].Triggers[0].Type != "redis" {
+		t.Errorf("Expected the trigger states to be recorded but got %v", scaledObject.Status.ScaleDecisions[1].Triggers)
+	}
+}
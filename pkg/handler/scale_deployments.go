@@ -12,33 +12,46 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-func (h *ScaleHandler) scaleDeployment(deployment *appsv1.Deployment, scaledObject *kedav1alpha1.ScaledObject, isActive bool) {
+func (h *ScaleHandler) scaleDeployment(deployment *appsv1.Deployment, scaledObject *kedav1alpha1.ScaledObject, isActive bool, getTriggers triggerDecisionsFunc) {
+	// the bounds can be overridden by a ReplicaSchedule, scaling above minReplicaCount is left to the HPA
+	minReplicaCount, maxReplicaCount, _, err := GetReplicaCountBounds(scaledObject, time.Now())
+	if err != nil {
+		h.logger.Error(err, "Error getting replica count bounds, using the ones from ScaledObject")
+	}
+	if minReplicaCount != nil && maxReplicaCount != nil && *minReplicaCount > *maxReplicaCount {
+		minReplicaCount = maxReplicaCount
+	}
 
 	if *deployment.Spec.Replicas == 0 && isActive {
 		// current replica count is 0, but there is an active trigger.
 		// scale the deployment up
-		h.scaleFromZero(deployment, scaledObject)
+		h.scaleFromZero(deployment, scaledObject, minReplicaCount, getTriggers)
 	} else if !isActive &&
 		*deployment.Spec.Replicas > 0 &&
-		(scaledObject.Spec.MinReplicaCount == nil || *scaledObject.Spec.MinReplicaCount == 0) {
+		(minReplicaCount == nil || *minReplicaCount == 0) {
 		// there are no active triggers, but the deployment has replicas.
 		// AND
 		// There is no minimum configured or minimum is set to ZERO. HPA will handles other scale down operations
 
 		// Try to scale it down.
-		h.scaleDeploymentToZero(deployment, scaledObject)
+		h.scaleDeploymentToZero(deployment, scaledObject, getTriggers)
 	} else if !isActive &&
-		scaledObject.Spec.MinReplicaCount != nil &&
-		*deployment.Spec.Replicas < *scaledObject.Spec.MinReplicaCount {
+		minReplicaCount != nil &&
+		*deployment.Spec.Replicas < *minReplicaCount {
 		// there are no active triggers
 		// AND
 		// deployment replicas count is less than minimum replica count specified in ScaledObject
 		// Let's set deployment replicas count to correct value
-		*deployment.Spec.Replicas = *scaledObject.Spec.MinReplicaCount
+		currentReplicas := *deployment.Spec.Replicas
+		*deployment.Spec.Replicas = *minReplicaCount
 
 		err := h.updateDeployment(deployment)
 		if err == nil {
 			h.logger.Info("Successfully set Deployment replicas count to ScaledObject minReplicaCount", "Deployment.Namespace", deployment.GetNamespace(), "Deployment.Name", deployment.GetName(), "Deployment.Replicas", *deployment.Spec.Replicas)
+
+			if recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonMinReplicaCount, currentReplicas, *deployment.Spec.Replicas, getTriggers) {
+				h.updateScaledObjectStatus(scaledObject)
+			}
 		}
 	} else if isActive {
 		// triggers are active, but we didn't need to scale (replica count > 0)
@@ -63,7 +76,7 @@ func (h *ScaleHandler) updateDeployment(deployment *appsv1.Deployment) error {
 
 // A deployment will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
-func (h *ScaleHandler) scaleDeploymentToZero(deployment *appsv1.Deployment, scaledObject *kedav1alpha1.ScaledObject) {
+func (h *ScaleHandler) scaleDeploymentToZero(deployment *appsv1.Deployment, scaledObject *kedav1alpha1.ScaledObject, getTriggers triggerDecisionsFunc) {
 	var cooldownPeriod time.Duration
 
 	if scaledObject.Spec.CooldownPeriod != nil {
@@ -77,10 +90,15 @@ func (h *ScaleHandler) scaleDeploymentToZero(deployment *appsv1.Deployment, scal
 	if scaledObject.Status.LastActiveTime == nil ||
 		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now()) {
 		// or last time a trigger was active was > cooldown period, so scale down.
+		currentReplicas := *deployment.Spec.Replicas
 		*deployment.Spec.Replicas = 0
 		err := h.updateDeployment(deployment)
 		if err == nil {
 			h.logger.Info("Successfully scaled deployment to 0 replicas", "Deployment.Namespace", deployment.GetNamespace(), "Deployment.Name", deployment.GetName())
+
+			if recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonDeactivation, currentReplicas, 0, getTriggers) {
+				h.updateScaledObjectStatus(scaledObject)
+			}
 		}
 	} else {
 		h.logger.V(1).Info("scaledObject cooling down",
@@ -88,13 +106,18 @@ func (h *ScaleHandler) scaleDeploymentToZero(deployment *appsv1.Deployment, scal
 			scaledObject.Status.LastActiveTime,
 			"CoolDownPeriod",
 			cooldownPeriod)
+
+		currentReplicas := *deployment.Spec.Replicas
+		if recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonCooldown, currentReplicas, currentReplicas, getTriggers) {
+			h.updateScaledObjectStatus(scaledObject)
+		}
 	}
 }
 
-func (h *ScaleHandler) scaleFromZero(deployment *appsv1.Deployment, scaledObject *kedav1alpha1.ScaledObject) {
+func (h *ScaleHandler) scaleFromZero(deployment *appsv1.Deployment, scaledObject *kedav1alpha1.ScaledObject, minReplicaCount *int32, getTriggers triggerDecisionsFunc) {
 	currentReplicas := *deployment.Spec.Replicas
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		deployment.Spec.Replicas = scaledObject.Spec.MinReplicaCount
+	if minReplicaCount != nil && *minReplicaCount > 0 {
+		*deployment.Spec.Replicas = *minReplicaCount
 	} else {
 		*deployment.Spec.Replicas = 1
 	}
@@ -111,6 +134,7 @@ func (h *ScaleHandler) scaleFromZero(deployment *appsv1.Deployment, scaledObject
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		now := metav1.Now()
 		scaledObject.Status.LastActiveTime = &now
+		recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonActivation, currentReplicas, *deployment.Spec.Replicas, getTriggers)
 		h.updateScaledObjectStatus(scaledObject)
 	}
 }
@@ -10,10 +10,10 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -22,8 +22,10 @@ import (
 // each ScaledObject and making the final scale decision and operation
 type ScaleHandler struct {
 	client           client.Client
+	clientset        kubernetes.Interface
 	logger           logr.Logger
 	reconcilerScheme *runtime.Scheme
+	activity         activityHistory
 }
 
 const (
@@ -34,9 +36,10 @@ const (
 )
 
 // NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, reconcilerScheme *runtime.Scheme) *ScaleHandler {
+func NewScaleHandler(client client.Client, clientset kubernetes.Interface, reconcilerScheme *runtime.Scheme) *ScaleHandler {
 	handler := &ScaleHandler{
 		client:           client,
+		clientset:        clientset,
 		logger:           logf.Log.WithName("scalehandler"),
 		reconcilerScheme: reconcilerScheme,
 	}
@@ -215,20 +218,13 @@ func (h *ScaleHandler) GetDeploymentScalers(scaledObject *kedav1alpha1.ScaledObj
 	for i, trigger := range scaledObject.Spec.Triggers {
 		authParams, podIdentity := h.parseDeploymentAuthRef(trigger.AuthenticationRef, scaledObject, deployment)
 
-		if podIdentity == kedav1alpha1.PodIdentityProviderAwsEKS {
-			serviceAccountName := deployment.Spec.Template.Spec.ServiceAccountName
-			serviceAccount := &v1.ServiceAccount{}
-			err = h.client.Get(context.TODO(), types.NamespacedName{Name: serviceAccountName, Namespace: scaledObject.GetNamespace()}, serviceAccount)
-			if err != nil {
-				closeScalers(scalersRes)
-				return []scalers.Scaler{}, nil, fmt.Errorf("error getting service account: %s", err)
-			}
-			authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
-		} else if podIdentity == kedav1alpha1.PodIdentityProviderAwsKiam {
-			authParams["awsRoleArn"] = deployment.Spec.Template.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
+		awsWebIdentity, err := h.resolveAwsPodIdentity(podIdentity, scaledObject.GetNamespace(), &deployment.Spec.Template, authParams)
+		if err != nil {
+			closeScalers(scalersRes)
+			return []scalers.Scaler{}, nil, err
 		}
 
-		scaler, err := h.getScaler(scaledObject.Name, scaledObject.Namespace, trigger.Type, resolvedEnv, trigger.Metadata, authParams, podIdentity)
+		scaler, err := h.getScaler(scaledObject.Name, scaledObject.Namespace, trigger.Type, resolvedEnv, trigger.Metadata, authParams, podIdentity, awsWebIdentity)
 		if err != nil {
 			closeScalers(scalersRes)
 			return []scalers.Scaler{}, nil, fmt.Errorf("error getting scaler for trigger #%d: %s", i, err)
@@ -250,7 +246,14 @@ func (h *ScaleHandler) getJobScalers(scaledObject *kedav1alpha1.ScaledObject) ([
 
 	for i, trigger := range scaledObject.Spec.Triggers {
 		authParams, podIdentity := h.parseJobAuthRef(trigger.AuthenticationRef, scaledObject)
-		scaler, err := h.getScaler(scaledObject.Name, scaledObject.Namespace, trigger.Type, resolvedEnv, trigger.Metadata, authParams, podIdentity)
+
+		awsWebIdentity, err := h.resolveAwsPodIdentity(podIdentity, scaledObject.GetNamespace(), &scaledObject.Spec.JobTargetRef.Template, authParams)
+		if err != nil {
+			closeScalers(scalersRes)
+			return []scalers.Scaler{}, err
+		}
+
+		scaler, err := h.getScaler(scaledObject.Name, scaledObject.Namespace, trigger.Type, resolvedEnv, trigger.Metadata, authParams, podIdentity, awsWebIdentity)
 		if err != nil {
 			closeScalers(scalersRes)
 			return []scalers.Scaler{}, fmt.Errorf("error getting scaler for trigger #%d: %s", i, err)
@@ -310,18 +313,44 @@ func (h *ScaleHandler) parseAuthRef(triggerAuthRef *kedav1alpha1.ScaledObjectAut
 	return result, podIdentity
 }
 
-func (h *ScaleHandler) getScaler(name, namespace, triggerType string, resolvedEnv, triggerMetadata, authParams map[string]string, podIdentity string) (scalers.Scaler, error) {
+// resolveAwsPodIdentity sets awsRoleArn for the AWS pod identities. For aws-eks it returns the web identity
+// of the service account of the scale target, its tokens are used to assume the role annotated on the service account.
+func (h *ScaleHandler) resolveAwsPodIdentity(podIdentity, namespace string, podTemplate *corev1.PodTemplateSpec, authParams map[string]string) (*scalers.AwsWebIdentity, error) {
+	switch podIdentity {
+	case kedav1alpha1.PodIdentityProviderAwsEKS:
+		serviceAccountName := podTemplate.Spec.ServiceAccountName
+		if serviceAccountName == "" {
+			serviceAccountName = "default"
+		}
+		serviceAccount := &corev1.ServiceAccount{}
+		err := h.client.Get(context.TODO(), types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, serviceAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error getting service account: %s", err)
+		}
+		roleArn := serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
+		if roleArn == "" {
+			return nil, fmt.Errorf("service account %s has no %s annotation", serviceAccountName, kedav1alpha1.PodIdentityAnnotationEKS)
+		}
+		authParams["awsRoleArn"] = roleArn
+		return scalers.NewAwsWebIdentity(h.clientset.CoreV1(), namespace, serviceAccountName), nil
+	case kedav1alpha1.PodIdentityProviderAwsKiam:
+		authParams["awsRoleArn"] = podTemplate.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
+	}
+	return nil, nil
+}
+
+func (h *ScaleHandler) getScaler(name, namespace, triggerType string, resolvedEnv, triggerMetadata, authParams map[string]string, podIdentity string, awsWebIdentity *scalers.AwsWebIdentity) (scalers.Scaler, error) {
 	switch triggerType {
 	case "azure-queue":
 		return scalers.NewAzureQueueScaler(resolvedEnv, triggerMetadata, authParams, podIdentity)
 	case "azure-servicebus":
 		return scalers.NewAzureServiceBusScaler(resolvedEnv, triggerMetadata, authParams, podIdentity)
 	case "aws-sqs-queue":
-		return scalers.NewAwsSqsQueueScaler(resolvedEnv, triggerMetadata, authParams)
+		return scalers.NewAwsSqsQueueScaler(resolvedEnv, triggerMetadata, authParams, awsWebIdentity)
 	case "aws-cloudwatch":
-		return scalers.NewAwsCloudwatchScaler(resolvedEnv, triggerMetadata, authParams)
+		return scalers.NewAwsCloudwatchScaler(resolvedEnv, triggerMetadata, authParams, awsWebIdentity)
 	case "aws-kinesis-stream":
-		return scalers.NewAwsKinesisStreamScaler(resolvedEnv, triggerMetadata, authParams)
+		return scalers.NewAwsKinesisStreamScaler(resolvedEnv, triggerMetadata, authParams, awsWebIdentity)
 	case "kafka":
 		return scalers.NewKafkaScaler(resolvedEnv, triggerMetadata, authParams)
 	case "rabbitmq":
@@ -329,9 +358,11 @@ func (h *ScaleHandler) getScaler(name, namespace, triggerType string, resolvedEn
 	case "azure-eventhub":
 		return scalers.NewAzureEventHubScaler(resolvedEnv, triggerMetadata)
 	case "prometheus":
-		return scalers.NewPrometheusScaler(resolvedEnv, triggerMetadata)
+		return scalers.NewPrometheusScaler(resolvedEnv, triggerMetadata, authParams)
 	case "redis":
 		return scalers.NewRedisScaler(resolvedEnv, triggerMetadata, authParams)
+	case "redis-streams":
+		return scalers.NewRedisStreamsScaler(resolvedEnv, triggerMetadata, authParams)
 	case "gcp-pubsub":
 		return scalers.NewPubSubScaler(resolvedEnv, triggerMetadata)
 	case "external":
@@ -350,6 +381,24 @@ func (h *ScaleHandler) getScaler(name, namespace, triggerType string, resolvedEn
 		return scalers.NewMySQLScaler(resolvedEnv, triggerMetadata, authParams)
 	case "azure-monitor":
 		return scalers.NewAzureMonitorScaler(resolvedEnv, triggerMetadata, authParams)
+	case "mssql":
+		return scalers.NewMSSQLScaler(resolvedEnv, triggerMetadata, authParams)
+	case "mongodb":
+		return scalers.NewMongoDBScaler(resolvedEnv, triggerMetadata, authParams)
+	case "elasticsearch":
+		return scalers.NewElasticsearchScaler(resolvedEnv, triggerMetadata, authParams)
+	case "metrics-api":
+		return scalers.NewMetricsAPIScaler(resolvedEnv, triggerMetadata, authParams)
+	case "nats-jetstream":
+		return scalers.NewNATSJetStreamScaler(resolvedEnv, triggerMetadata)
+	case "pulsar":
+		return scalers.NewPulsarScaler(resolvedEnv, triggerMetadata, authParams)
+	case "activemq":
+		return scalers.NewActiveMQScaler(resolvedEnv, triggerMetadata, authParams)
+	case "artemis-queue":
+		return scalers.NewArtemisQueueScaler(resolvedEnv, triggerMetadata, authParams)
+	case "aws-dynamodb":
+		return scalers.NewAwsDynamoDBScaler(resolvedEnv, triggerMetadata, authParams, awsWebIdentity)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
@@ -3,7 +3,11 @@ package handler
 import (
 	"testing"
 
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -108,7 +112,7 @@ var testMetadatas = []testMetadata{
 func TestResolveNonExistingConfigMapsOrSecretsEnv(t *testing.T) {
 
 	for _, testData := range testMetadatas {
-		testScaleHandler := NewScaleHandler(fake.NewFakeClient(), scheme.Scheme)
+		testScaleHandler := NewScaleHandler(fake.NewFakeClient(), nil, scheme.Scheme)
 
 		_, err := testScaleHandler.resolveEnv(testData.container, namespace)
 
@@ -121,3 +125,86 @@ func TestResolveNonExistingConfigMapsOrSecretsEnv(t *testing.T) {
 		}
 	}
 }
+
+type awsPodIdentityTestData struct {
+	podIdentity         string
+	podTemplate         corev1.PodTemplateSpec
+	expectedRoleArn     string
+	expectedWebIdentity string
+	isError             bool
+	comment             string
+}
+
+var testAwsPodIdentities = []awsPodIdentityTestData{
+	{
+		podIdentity:         kedav1alpha1.PodIdentityProviderAwsEKS,
+		podTemplate:         corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: "workload"}},
+		expectedRoleArn:     "arn:aws:iam::111111111111:role/workload",
+		expectedWebIdentity: namespace + "/workload",
+		comment:             "aws-eks uses the service account of the pod spec"},
+	{
+		podIdentity:         kedav1alpha1.PodIdentityProviderAwsEKS,
+		podTemplate:         corev1.PodTemplateSpec{},
+		expectedRoleArn:     "arn:aws:iam::111111111111:role/default",
+		expectedWebIdentity: namespace + "/default",
+		comment:             "aws-eks uses the default service account when the pod spec doesn't set one"},
+	{
+		podIdentity: kedav1alpha1.PodIdentityProviderAwsEKS,
+		podTemplate: corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: "no-role"}},
+		isError:     true,
+		comment:     "aws-eks with a service account without role annotation"},
+	{
+		podIdentity: kedav1alpha1.PodIdentityProviderAwsEKS,
+		podTemplate: corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: "do-not-exist"}},
+		isError:     true,
+		comment:     "aws-eks with a service account that doesn't exist"},
+	{
+		podIdentity: kedav1alpha1.PodIdentityProviderAwsKiam,
+		podTemplate: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{kedav1alpha1.PodIdentityAnnotationKiam: "arn:aws:iam::111111111111:role/kiam"}},
+			Spec:       corev1.PodSpec{ServiceAccountName: "workload"},
+		},
+		expectedRoleArn: "arn:aws:iam::111111111111:role/kiam",
+		comment:         "aws-kiam uses the pod annotation and the credentials of the operator"},
+	{
+		podIdentity: "",
+		podTemplate: corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: "workload"}},
+		comment:     "no pod identity"},
+}
+
+func TestResolveAwsPodIdentity(t *testing.T) {
+	serviceAccount := func(name string, annotations map[string]string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations}}
+	}
+	client := fake.NewFakeClient(
+		serviceAccount("workload", map[string]string{kedav1alpha1.PodIdentityAnnotationEKS: "arn:aws:iam::111111111111:role/workload"}),
+		serviceAccount("default", map[string]string{kedav1alpha1.PodIdentityAnnotationEKS: "arn:aws:iam::111111111111:role/default"}),
+		serviceAccount("no-role", nil),
+	)
+	testScaleHandler := NewScaleHandler(client, kubefake.NewSimpleClientset(), scheme.Scheme)
+
+	for _, testData := range testAwsPodIdentities {
+		authParams := map[string]string{}
+		webIdentity, err := testScaleHandler.resolveAwsPodIdentity(testData.podIdentity, namespace, &testData.podTemplate, authParams)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success because %s got error, %s", testData.comment, err)
+		}
+		if testData.isError {
+			if err == nil {
+				t.Errorf("Expected error because %s but got success", testData.comment)
+			}
+			continue
+		}
+
+		if authParams["awsRoleArn"] != testData.expectedRoleArn {
+			t.Errorf("%s: expected awsRoleArn %s but got %s", testData.comment, testData.expectedRoleArn, authParams["awsRoleArn"])
+		}
+		if testData.expectedWebIdentity == "" {
+			if webIdentity != nil {
+				t.Errorf("%s: expected no web identity but got %s", testData.comment, webIdentity)
+			}
+		} else if webIdentity == nil || webIdentity.String() != testData.expectedWebIdentity {
+			t.Errorf("%s: expected the web identity of %s but got %v", testData.comment, testData.expectedWebIdentity, webIdentity)
+		}
+	}
+}
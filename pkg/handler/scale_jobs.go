@@ -15,7 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func (h *ScaleHandler) scaleJobs(scaledObject *kedav1alpha1.ScaledObject, isActive bool, scaleTo int64, maxScale int64) {
+func (h *ScaleHandler) scaleJobs(scaledObject *kedav1alpha1.ScaledObject, isActive bool, scaleTo int64, maxScale int64, triggers []kedav1alpha1.TriggerDecision) {
 	runningJobCount := h.getRunningJobCount(scaledObject, maxScale)
 	h.logger.Info("Scaling Jobs", "Number of running Jobs ", runningJobCount)
 
@@ -31,6 +31,16 @@ func (h *ScaleHandler) scaleJobs(scaledObject *kedav1alpha1.ScaledObject, isActi
 		h.logger.V(1).Info("At least one scaler is active")
 		now := metav1.Now()
 		scaledObject.Status.LastActiveTime = &now
+		jobsToCreate := scaleTo
+		if jobsToCreate > effectiveMaxScale {
+			jobsToCreate = effectiveMaxScale
+		}
+		if jobsToCreate > 0 {
+			recordScaleDecision(scaledObject, kedav1alpha1.ScaleDecisionReasonActivation, int32(runningJobCount), int32(runningJobCount+jobsToCreate), func() []kedav1alpha1.TriggerDecision {
+				// the values were already read to compute the queue length
+				return triggers
+			})
+		}
 		h.updateScaledObjectStatus(scaledObject)
 		h.createJobs(scaledObject, scaleTo, effectiveMaxScale)
 
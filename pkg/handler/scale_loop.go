@@ -5,6 +5,7 @@ import (
 	"time"
 
 	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/pkg/scalers"
 )
 
 // HandleScaleLoop blocks forever and checks the scaledObject based on its pollingInterval
@@ -58,11 +59,12 @@ func (h *ScaleHandler) handleScaleJob(ctx context.Context, scaledObject *kedav1a
 	}
 
 	isScaledObjectActive := false
+	triggers := []kedav1alpha1.TriggerDecision{}
 	h.logger.Info("Scalers count", "Count", len(scalers))
 	var queueLength int64
 	var maxValue int64
 
-	for _, scaler := range scalers {
+	for i, scaler := range scalers {
 		scalerLogger := h.logger.WithValues("Scaler", scaler)
 
 		isTriggerActive, err := scaler.IsActive(ctx)
@@ -71,19 +73,27 @@ func (h *ScaleHandler) handleScaleJob(ctx context.Context, scaledObject *kedav1a
 
 		var metricValue int64
 		for _, metric := range metricSpecs {
-			metricValue, _ = metric.External.TargetAverageValue.AsInt64()
+			// fractional values are rounded up, AsInt64 would fail on them
+			metricValue = metric.External.TargetAverageValue.Value()
 			maxValue += metricValue
 		}
 		scalerLogger.Info("Scaler max value", "MaxValue", maxValue)
 
 		metrics, _ := scaler.GetMetrics(ctx, "queueLength", nil)
 
+		triggerDecision := kedav1alpha1.TriggerDecision{
+			Type:   scaledObject.Spec.Triggers[i].Type,
+			Name:   scaledObject.Spec.Triggers[i].Name,
+			Active: isTriggerActive,
+		}
 		for _, m := range metrics {
 			if m.MetricName == "queueLength" {
-				metricValue, _ = m.Value.AsInt64()
+				metricValue = m.Value.Value()
 				queueLength += metricValue
+				triggerDecision.Value = m.Value.String()
 			}
 		}
+		triggers = append(triggers, triggerDecision)
 		scalerLogger.Info("QueueLength Metric value", "queueLength", queueLength)
 
 		if err != nil {
@@ -96,13 +106,13 @@ func (h *ScaleHandler) handleScaleJob(ctx context.Context, scaledObject *kedav1a
 		scaler.Close()
 	}
 
-	h.scaleJobs(scaledObject, isScaledObjectActive, queueLength, maxValue)
+	h.scaleJobs(scaledObject, isScaledObjectActive, queueLength, maxValue, triggers)
 }
 
 // handleScaleDeployment contains the main logic for the ScaleHandler scaling logic.
 // It'll check each trigger active status then call scaleDeployment
 func (h *ScaleHandler) handleScaleDeployment(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) {
-	scalers, deployment, err := h.GetDeploymentScalers(scaledObject)
+	deploymentScalers, deployment, err := h.GetDeploymentScalers(scaledObject)
 
 	if deployment == nil {
 		return
@@ -113,8 +123,10 @@ func (h *ScaleHandler) handleScaleDeployment(ctx context.Context, scaledObject *
 	}
 
 	isScaledObjectActive := false
+	triggers := []kedav1alpha1.TriggerDecision{}
+	triggerScalers := []scalers.Scaler{}
 
-	for _, scaler := range scalers {
+	for i, scaler := range deploymentScalers {
 		defer scaler.Close()
 		isTriggerActive, err := scaler.IsActive(ctx)
 
@@ -125,7 +137,15 @@ func (h *ScaleHandler) handleScaleDeployment(ctx context.Context, scaledObject *
 			isScaledObjectActive = true
 			h.logger.V(1).Info("Scaler for scaledObject is active", "Scaler", scaler)
 		}
+		triggers = append(triggers, kedav1alpha1.TriggerDecision{
+			Type:   scaledObject.Spec.Triggers[i].Type,
+			Name:   scaledObject.Spec.Triggers[i].Name,
+			Active: isTriggerActive,
+		})
+		triggerScalers = append(triggerScalers, scaler)
 	}
 
-	h.scaleDeployment(deployment, scaledObject, isScaledObjectActive)
+	isScaledObjectActive = h.getStabilizedActivity(scaledObject, isScaledObjectActive, *deployment.Spec.Replicas > 0)
+
+	h.scaleDeployment(deployment, scaledObject, isScaledObjectActive, h.getTriggerDecisions(ctx, triggers, triggerScalers))
 }
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/pkg/apis/keda/v1alpha1"
+)
+
+// activityRecord is a single IsActive outcome of the ScaledObject's triggers
+type activityRecord struct {
+	time     time.Time
+	isActive bool
+}
+
+// activityHistory keeps the recent IsActive outcomes of a ScaledObject together with
+// its stabilized active state, which only changes once the new outcome has held for the stabilization window
+type activityHistory struct {
+	records     []activityRecord
+	isActive    bool
+	initialized bool
+}
+
+// stabilize records the isActive outcome and returns the stabilized active state.
+// wasActive is used as the initial state when the history is empty, eg. after the ScaleLoop was restarted.
+func (a *activityHistory) stabilize(isActive bool, wasActive bool, now time.Time, activationWindow, deactivationWindow time.Duration) bool {
+	if !a.initialized {
+		a.isActive = wasActive
+		a.initialized = true
+	}
+
+	a.records = append(a.records, activityRecord{time: now, isActive: isActive})
+
+	// keep only the records needed to cover the longest window
+	maxWindow := activationWindow
+	if deactivationWindow > maxWindow {
+		maxWindow = deactivationWindow
+	}
+	for len(a.records) > 1 && !a.records[1].time.After(now.Add(-maxWindow)) {
+		a.records = a.records[1:]
+	}
+
+	if isActive == a.isActive {
+		return a.isActive
+	}
+
+	window := deactivationWindow
+	if isActive {
+		window = activationWindow
+	}
+	if now.Sub(a.heldSince()) >= window {
+		a.isActive = isActive
+	}
+	return a.isActive
+}
+
+// heldSince returns the time of the oldest record in the trailing run of identical outcomes
+func (a *activityHistory) heldSince() time.Time {
+	last := len(a.records) - 1
+	since := a.records[last].time
+	for i := last - 1; i >= 0 && a.records[i].isActive == a.records[last].isActive; i-- {
+		since = a.records[i].time
+	}
+	return since
+}
+
+// getStabilizedActivity returns the active state of the ScaledObject, taking into account
+// its activationStabilizationWindow and deactivationStabilizationWindow
+func (h *ScaleHandler) getStabilizedActivity(scaledObject *kedav1alpha1.ScaledObject, isActive bool, wasActive bool) bool {
+	var activationWindow, deactivationWindow time.Duration
+	if scaledObject.Spec.ActivationStabilizationWindow != nil {
+		activationWindow = time.Second * time.Duration(*scaledObject.Spec.ActivationStabilizationWindow)
+	}
+	if scaledObject.Spec.DeactivationStabilizationWindow != nil {
+		deactivationWindow = time.Second * time.Duration(*scaledObject.Spec.DeactivationStabilizationWindow)
+	}
+
+	stabilized := h.activity.stabilize(isActive, wasActive, time.Now(), activationWindow, deactivationWindow)
+	if stabilized != isActive {
+		h.logger.V(1).Info("Active state of scaledObject is not stable yet",
+			"isActive", isActive,
+			"ActivationStabilizationWindow", activationWindow,
+			"DeactivationStabilizationWindow", deactivationWindow)
+	}
+	return stabilized
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+type stabilizationTestStep struct {
+	second   int
+	isActive bool
+	expected bool
+}
+
+type stabilizationTestData struct {
+	comment            string
+	wasActive          bool
+	activationWindow   time.Duration
+	deactivationWindow time.Duration
+	steps              []stabilizationTestStep
+}
+
+var stabilizationTestDataset = []stabilizationTestData{
+	{"no windows", false, 0, 0, []stabilizationTestStep{{0, true, true}, {30, false, false}, {60, true, true}}},
+	{"activation held for the window", false, 60 * time.Second, 0, []stabilizationTestStep{{0, true, false}, {30, true, false}, {60, true, true}}},
+	{"flapping trigger doesn't activate", false, 60 * time.Second, 0, []stabilizationTestStep{{0, true, false}, {30, false, false}, {60, true, false}, {90, true, false}, {120, true, true}}},
+	{"deactivation held for the window", true, 0, 60 * time.Second, []stabilizationTestStep{{0, false, true}, {30, true, true}, {60, false, true}, {90, false, true}, {120, false, false}}},
+	{"initial state is kept", true, 60 * time.Second, 60 * time.Second, []stabilizationTestStep{{0, true, true}, {30, false, true}, {60, true, true}}},
+}
+
+func TestActivityHistoryStabilize(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, testData := range stabilizationTestDataset {
+		history := activityHistory{}
+		for _, step := range testData.steps {
+			now := start.Add(time.Duration(step.second) * time.Second)
+			isActive := history.stabilize(step.isActive, testData.wasActive, now, testData.activationWindow, testData.deactivationWindow)
+			if isActive != step.expected {
+				t.Errorf("%s: expected active state %t at %ds but got %t", testData.comment, step.expected, step.second, isActive)
+			}
+		}
+	}
+}
@@ -19,11 +19,11 @@ import (
 
 // KedaProvider implements External Metrics Provider
 type KedaProvider struct {
-	client           client.Client
-	values           map[provider.CustomMetricInfo]int64
-	externalMetrics  []externalMetric
-	scaleHandler     *handler.ScaleHandler
-	watchedNamespace string
+	client            client.Client
+	values            map[provider.CustomMetricInfo]int64
+	externalMetrics   []externalMetric
+	scaleHandler      *handler.ScaleHandler
+	watchedNamespaces []string
 }
 type externalMetric struct {
 	info   provider.ExternalMetricInfo
@@ -34,13 +34,14 @@ type externalMetric struct {
 var logger logr.Logger
 
 // NewProvider returns an instance of KedaProvider
-func NewProvider(adapterLogger logr.Logger, scaleHandler *handler.ScaleHandler, client client.Client, watchedNamespace string) provider.MetricsProvider {
+// watchedNamespaces holds the namespaces watched by the operator, empty list means all namespaces
+func NewProvider(adapterLogger logr.Logger, scaleHandler *handler.ScaleHandler, client client.Client, watchedNamespaces []string) provider.MetricsProvider {
 	provider := &KedaProvider{
-		values:           make(map[provider.CustomMetricInfo]int64),
-		externalMetrics:  make([]externalMetric, 2, 10),
-		client:           client,
-		scaleHandler:     scaleHandler,
-		watchedNamespace: watchedNamespace,
+		values:            make(map[provider.CustomMetricInfo]int64),
+		externalMetrics:   make([]externalMetric, 2, 10),
+		client:            client,
+		scaleHandler:      scaleHandler,
+		watchedNamespaces: watchedNamespaces,
 	}
 	logger = adapterLogger.WithName("provider")
 	logger.Info("starting")
@@ -104,21 +105,29 @@ func (p *KedaProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
 
 	externalMetricsInfo := []provider.ExternalMetricInfo{}
 
-	//get all ScaledObjects in namespace(s) watched by the operator
-	scaledObjects := &kedav1alpha1.ScaledObjectList{}
-	opts := []client.ListOption{
-		client.InNamespace(p.watchedNamespace),
-	}
-	err := p.client.List(context.TODO(), scaledObjects, opts...)
-	if err != nil {
-		logger.Error(err, "Cannot get list of ScaledObjects", "WatchedNamespace", p.watchedNamespace)
-		return nil
+	// empty namespace lists ScaledObjects in all namespaces
+	namespaces := p.watchedNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
 	}
 
-	// get metrics from all watched ScaledObjects
-	for _, scaledObject := range scaledObjects.Items {
-		for _, metric := range scaledObject.Status.ExternalMetricNames {
-			externalMetricsInfo = append(externalMetricsInfo, provider.ExternalMetricInfo{Metric: metric})
+	//get all ScaledObjects in namespace(s) watched by the operator
+	for _, namespace := range namespaces {
+		scaledObjects := &kedav1alpha1.ScaledObjectList{}
+		opts := []client.ListOption{
+			client.InNamespace(namespace),
+		}
+		err := p.client.List(context.TODO(), scaledObjects, opts...)
+		if err != nil {
+			logger.Error(err, "Cannot get list of ScaledObjects", "WatchedNamespace", namespace)
+			return nil
+		}
+
+		// get metrics from all watched ScaledObjects
+		for _, scaledObject := range scaledObjects.Items {
+			for _, metric := range scaledObject.Status.ExternalMetricNames {
+				externalMetricsInfo = append(externalMetricsInfo, provider.ExternalMetricInfo{Metric: metric})
+			}
 		}
 	}
 	return externalMetricsInfo
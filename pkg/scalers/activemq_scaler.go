@@ -0,0 +1,151 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	activeMQTargetQueueSize        = "targetQueueSize"
+	defaultActiveMQTargetQueueSize = 10
+)
+
+type activeMQScaler struct {
+	metadata   *activeMQMetadata
+	httpClient *http.Client
+}
+
+type activeMQMetadata struct {
+	managementEndpoint string
+	brokerName         string
+	destinationName    string
+	targetQueueSize    float64
+	username           string
+	password           string
+}
+
+var activeMQLog = logf.Log.WithName("activemq_scaler")
+
+// NewActiveMQScaler creates a new ActiveMQ Classic scaler reading the queue size through Jolokia
+func NewActiveMQScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseActiveMQMetadata(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing activemq metadata: %s", err)
+	}
+
+	return &activeMQScaler{
+		metadata:   meta,
+		httpClient: &http.Client{Timeout: jolokiaHTTPTimeout},
+	}, nil
+}
+
+func parseActiveMQMetadata(metadata, resolvedEnv, authParams map[string]string) (*activeMQMetadata, error) {
+	meta := activeMQMetadata{}
+
+	if val, ok := metadata["managementEndpoint"]; ok && val != "" {
+		meta.managementEndpoint = strings.TrimRight(val, "/")
+	} else {
+		return nil, fmt.Errorf("no managementEndpoint given")
+	}
+
+	if val, ok := metadata["brokerName"]; ok && val != "" {
+		meta.brokerName = val
+	} else {
+		return nil, fmt.Errorf("no brokerName given")
+	}
+
+	if val, ok := metadata["destinationName"]; ok && val != "" {
+		meta.destinationName = val
+	} else {
+		return nil, fmt.Errorf("no destinationName given")
+	}
+
+	meta.targetQueueSize = defaultActiveMQTargetQueueSize
+	if val, ok := metadata[activeMQTargetQueueSize]; ok && val != "" {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", activeMQTargetQueueSize, err)
+		}
+		meta.targetQueueSize = t
+	}
+
+	if val, ok := authParams["username"]; ok && val != "" {
+		meta.username = val
+	} else if val, ok := metadata["username"]; ok && val != "" {
+		meta.username = val
+	} else {
+		return nil, fmt.Errorf("no username given")
+	}
+
+	if val, ok := authParams["password"]; ok && val != "" {
+		meta.password = val
+	} else if val, ok := metadata["password"]; ok && val != "" {
+		meta.password = resolvedEnv[val]
+	}
+	if meta.password == "" {
+		return nil, fmt.Errorf("no password given")
+	}
+
+	return &meta, nil
+}
+
+// getJolokiaURL returns the Jolokia read url of the QueueSize attribute of the destination
+func (s *activeMQScaler) getJolokiaURL() string {
+	mbean := fmt.Sprintf("org.apache.activemq:type=Broker,brokerName=%s,destinationType=Queue,destinationName=%s", s.metadata.brokerName, s.metadata.destinationName)
+	return fmt.Sprintf("http://%s/api/jolokia/read/%s/QueueSize", s.metadata.managementEndpoint, escapeJolokiaPath(mbean))
+}
+
+// IsActive returns true if there are messages in the queue
+func (s *activeMQScaler) IsActive(ctx context.Context) (bool, error) {
+	queueSize, err := getJolokiaAttribute(ctx, s.httpClient, s.getJolokiaURL(), s.metadata.username, s.metadata.password)
+	if err != nil {
+		activeMQLog.Error(err, "Unable to read the activemq queue size", "destinationName", s.metadata.destinationName)
+		return false, err
+	}
+
+	return queueSize > 0, nil
+}
+
+func (s *activeMQScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	return []v2beta1.MetricSpec{
+		{
+			External: &v2beta1.ExternalMetricSource{
+				MetricName:         sanitizeMetricName(fmt.Sprintf("%s-%s-%s", "activemq", s.metadata.brokerName, s.metadata.destinationName)),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.targetQueueSize*1000), resource.DecimalSI),
+			},
+			Type: externalMetricType,
+		},
+	}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *activeMQScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	queueSize, err := getJolokiaAttribute(ctx, s.httpClient, s.getJolokiaURL(), s.metadata.username, s.metadata.password)
+	if err != nil {
+		activeMQLog.Error(err, "Unable to read the activemq queue size", "destinationName", s.metadata.destinationName)
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(queueSize*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// Close does nothing for the activemq scaler
+func (s *activeMQScaler) Close() error {
+	return nil
+}
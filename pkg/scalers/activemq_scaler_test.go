@@ -0,0 +1,114 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testActiveMQResolvedEnv = map[string]string{
+	"ACTIVEMQ_PASSWORD": "pass",
+}
+
+type parseActiveMQMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testActiveMQMetadata = []parseActiveMQMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed, credentials from authParams
+	{map[string]string{"managementEndpoint": "activemq:8161", "brokerName": "localhost", "destinationName": "orders", "targetQueueSize": "50"}, map[string]string{"username": "admin", "password": "pass"}, false},
+	// password from env
+	{map[string]string{"managementEndpoint": "activemq:8161", "brokerName": "localhost", "destinationName": "orders", "username": "admin", "password": "ACTIVEMQ_PASSWORD"}, map[string]string{}, false},
+	// missing managementEndpoint
+	{map[string]string{"brokerName": "localhost", "destinationName": "orders"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing brokerName
+	{map[string]string{"managementEndpoint": "activemq:8161", "destinationName": "orders"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing destinationName
+	{map[string]string{"managementEndpoint": "activemq:8161", "brokerName": "localhost"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// malformed targetQueueSize
+	{map[string]string{"managementEndpoint": "activemq:8161", "brokerName": "localhost", "destinationName": "orders", "targetQueueSize": "fifty"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing username
+	{map[string]string{"managementEndpoint": "activemq:8161", "brokerName": "localhost", "destinationName": "orders"}, map[string]string{"password": "pass"}, true},
+	// missing password
+	{map[string]string{"managementEndpoint": "activemq:8161", "brokerName": "localhost", "destinationName": "orders"}, map[string]string{"username": "admin"}, true},
+}
+
+func TestParseActiveMQMetadata(t *testing.T) {
+	for _, testData := range testActiveMQMetadata {
+		_, err := parseActiveMQMetadata(testData.metadata, testActiveMQResolvedEnv, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestActiveMQGetMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// the / of the destination name is escaped the Jolokia way, %2F is rejected by Jetty
+		if strings.Contains(r.RequestURI, "%2F") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		expectedPath := "/api/jolokia/read/org.apache.activemq:type=Broker,brokerName=localhost,destinationType=Queue,destinationName=orders!/eu/QueueSize"
+		if r.URL.Path != expectedPath {
+			// Jolokia answers unknown MBeans with a 200 and the error in the body
+			fmt.Fprint(w, `{"error_type": "javax.management.InstanceNotFoundException", "error": "not found", "status": 404}`)
+			return
+		}
+		fmt.Fprint(w, `{"request": {"mbean": "org.apache.activemq:brokerName=localhost,destinationName=orders,destinationType=Queue,type=Broker", "attribute": "QueueSize", "type": "read"}, "value": 42, "timestamp": 1585000000, "status": 200}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"managementEndpoint": strings.TrimPrefix(server.URL, "http://"), "brokerName": "localhost", "destinationName": "orders/eu"}
+	scaler, err := NewActiveMQScaler(map[string]string{}, metadata, map[string]string{"username": "admin", "password": "pass"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "activemq-localhost-orders-eu", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if metrics[0].Value.Value() != 42 {
+		t.Errorf("Expected value 42 but got %d", metrics[0].Value.Value())
+	}
+
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "activemq-localhost-orders-eu" {
+		t.Errorf("Expected metric activemq-localhost-orders-eu but got %s", name)
+	}
+
+	// unknown destination
+	metadata["destinationName"] = "payments"
+	scaler, err = NewActiveMQScaler(map[string]string{}, metadata, map[string]string{"username": "admin", "password": "pass"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error for unknown destination but got success")
+	}
+
+	// wrong credentials
+	metadata["destinationName"] = "orders"
+	scaler, err = NewActiveMQScaler(map[string]string{}, metadata, map[string]string{"username": "admin", "password": "wrong"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error for wrong credentials but got success")
+	}
+}
@@ -0,0 +1,169 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	artemisQueueLength        = "queueLength"
+	defaultArtemisQueueLength = 10
+	defaultArtemisRoutingType = "anycast"
+)
+
+type artemisScaler struct {
+	metadata   *artemisMetadata
+	httpClient *http.Client
+}
+
+type artemisMetadata struct {
+	managementEndpoint string
+	brokerName         string
+	brokerAddress      string
+	queueName          string
+	routingType        string
+	queueLength        float64
+	username           string
+	password           string
+}
+
+var artemisLog = logf.Log.WithName("artemis_queue_scaler")
+
+// NewArtemisQueueScaler creates a new ActiveMQ Artemis scaler reading the queue message count through Jolokia
+func NewArtemisQueueScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseArtemisMetadata(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing artemis metadata: %s", err)
+	}
+
+	return &artemisScaler{
+		metadata:   meta,
+		httpClient: &http.Client{Timeout: jolokiaHTTPTimeout},
+	}, nil
+}
+
+func parseArtemisMetadata(metadata, resolvedEnv, authParams map[string]string) (*artemisMetadata, error) {
+	meta := artemisMetadata{}
+
+	if val, ok := metadata["managementEndpoint"]; ok && val != "" {
+		meta.managementEndpoint = strings.TrimRight(val, "/")
+	} else {
+		return nil, fmt.Errorf("no managementEndpoint given")
+	}
+
+	if val, ok := metadata["brokerName"]; ok && val != "" {
+		meta.brokerName = val
+	} else {
+		return nil, fmt.Errorf("no brokerName given")
+	}
+
+	if val, ok := metadata["queueName"]; ok && val != "" {
+		meta.queueName = val
+	} else {
+		return nil, fmt.Errorf("no queueName given")
+	}
+
+	// anycast queues are usually named after their address
+	meta.brokerAddress = meta.queueName
+	if val, ok := metadata["brokerAddress"]; ok && val != "" {
+		meta.brokerAddress = val
+	}
+
+	meta.routingType = defaultArtemisRoutingType
+	if val, ok := metadata["routingType"]; ok && val != "" {
+		if val != "anycast" && val != "multicast" {
+			return nil, fmt.Errorf("err incorrect value for routingType given: %s, must be anycast or multicast", val)
+		}
+		meta.routingType = val
+	}
+
+	meta.queueLength = defaultArtemisQueueLength
+	if val, ok := metadata[artemisQueueLength]; ok && val != "" {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", artemisQueueLength, err)
+		}
+		meta.queueLength = t
+	}
+
+	if val, ok := authParams["username"]; ok && val != "" {
+		meta.username = val
+	} else if val, ok := metadata["username"]; ok && val != "" {
+		meta.username = val
+	} else {
+		return nil, fmt.Errorf("no username given")
+	}
+
+	if val, ok := authParams["password"]; ok && val != "" {
+		meta.password = val
+	} else if val, ok := metadata["password"]; ok && val != "" {
+		meta.password = resolvedEnv[val]
+	}
+	if meta.password == "" {
+		return nil, fmt.Errorf("no password given")
+	}
+
+	return &meta, nil
+}
+
+// getJolokiaURL returns the Jolokia read url of the MessageCount attribute of the queue
+func (s *artemisScaler) getJolokiaURL() string {
+	mbean := fmt.Sprintf(`org.apache.activemq.artemis:broker="%s",component=addresses,address="%s",subcomponent=queues,routing-type="%s",queue="%s"`,
+		s.metadata.brokerName, s.metadata.brokerAddress, s.metadata.routingType, s.metadata.queueName)
+	return fmt.Sprintf("http://%s/console/jolokia/read/%s/MessageCount", s.metadata.managementEndpoint, escapeJolokiaPath(mbean))
+}
+
+// IsActive returns true if there are messages in the queue
+func (s *artemisScaler) IsActive(ctx context.Context) (bool, error) {
+	messageCount, err := getJolokiaAttribute(ctx, s.httpClient, s.getJolokiaURL(), s.metadata.username, s.metadata.password)
+	if err != nil {
+		artemisLog.Error(err, "Unable to read the artemis queue message count", "queueName", s.metadata.queueName)
+		return false, err
+	}
+
+	return messageCount > 0, nil
+}
+
+func (s *artemisScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	return []v2beta1.MetricSpec{
+		{
+			External: &v2beta1.ExternalMetricSource{
+				MetricName:         sanitizeMetricName(fmt.Sprintf("%s-%s-%s", "artemis", s.metadata.brokerName, s.metadata.queueName)),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.queueLength*1000), resource.DecimalSI),
+			},
+			Type: externalMetricType,
+		},
+	}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *artemisScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	messageCount, err := getJolokiaAttribute(ctx, s.httpClient, s.getJolokiaURL(), s.metadata.username, s.metadata.password)
+	if err != nil {
+		artemisLog.Error(err, "Unable to read the artemis queue message count", "queueName", s.metadata.queueName)
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(messageCount*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// Close does nothing for the artemis scaler
+func (s *artemisScaler) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testArtemisResolvedEnv = map[string]string{
+	"ARTEMIS_PASSWORD": "pass",
+}
+
+type parseArtemisMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testArtemisMetadata = []parseArtemisMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed, credentials from authParams
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker", "queueName": "orders", "queueLength": "50"}, map[string]string{"username": "admin", "password": "pass"}, false},
+	// address, routing type and password from env
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker", "brokerAddress": "events", "queueName": "orders", "routingType": "multicast", "username": "admin", "password": "ARTEMIS_PASSWORD"}, map[string]string{}, false},
+	// unknown routing type
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker", "queueName": "orders", "routingType": "broadcast"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing managementEndpoint
+	{map[string]string{"brokerName": "broker", "queueName": "orders"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing brokerName
+	{map[string]string{"managementEndpoint": "artemis:8161", "queueName": "orders"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing queueName
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// malformed queueLength
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker", "queueName": "orders", "queueLength": "fifty"}, map[string]string{"username": "admin", "password": "pass"}, true},
+	// missing username
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker", "queueName": "orders"}, map[string]string{"password": "pass"}, true},
+	// password env does not resolve
+	{map[string]string{"managementEndpoint": "artemis:8161", "brokerName": "broker", "queueName": "orders", "password": "ARTEMIS_WRONG"}, map[string]string{"username": "admin"}, true},
+}
+
+func TestParseArtemisMetadata(t *testing.T) {
+	for _, testData := range testArtemisMetadata {
+		_, err := parseArtemisMetadata(testData.metadata, testArtemisResolvedEnv, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestArtemisGetMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		expectedPath := `/console/jolokia/read/org.apache.activemq.artemis:broker="broker",component=addresses,address="events",subcomponent=queues,routing-type="anycast",queue="orders"/MessageCount`
+		if r.URL.Path != expectedPath {
+			fmt.Fprint(w, `{"error_type": "javax.management.InstanceNotFoundException", "error": "not found", "status": 404}`)
+			return
+		}
+		fmt.Fprint(w, `{"request": {"attribute": "MessageCount", "type": "read"}, "value": 0, "timestamp": 1585000000, "status": 200}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"managementEndpoint": strings.TrimPrefix(server.URL, "http://"), "brokerName": "broker", "brokerAddress": "events", "queueName": "orders"}
+	scaler, err := NewArtemisQueueScaler(map[string]string{}, metadata, map[string]string{"username": "admin", "password": "pass"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	isActive, err := scaler.IsActive(context.TODO())
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if isActive {
+		t.Error("Expected inactive but got active")
+	}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "artemis-broker-orders", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if metrics[0].Value.Value() != 0 {
+		t.Errorf("Expected value 0 but got %d", metrics[0].Value.Value())
+	}
+
+	// the address defaults to the queue name
+	delete(metadata, "brokerAddress")
+	scaler, err = NewArtemisQueueScaler(map[string]string{}, metadata, map[string]string{"username": "admin", "password": "pass"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error for unknown address but got success")
+	}
+}
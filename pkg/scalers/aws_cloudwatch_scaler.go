@@ -2,15 +2,14 @@ package scalers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -25,6 +24,9 @@ const (
 	defaultMetricCollectionTime = 300
 	defaultMetricStat           = "Average"
 	defaultMetricStatPeriod     = 300
+
+	cloudwatchMetricQueryID     = "c1"
+	cloudwatchExpressionQueryID = "e1"
 )
 
 type awsCloudwatchScaler struct {
@@ -34,8 +36,10 @@ type awsCloudwatchScaler struct {
 type awsCloudwatchMetadata struct {
 	namespace      string
 	metricsName    string
-	dimensionName  string
-	dimensionValue string
+	dimensionName  []string
+	dimensionValue []string
+	// expression is a metric math expression, it can refer to the metric as c1 or with METRICS()
+	expression string
 
 	targetMetricValue float64
 	minMetricValue    float64
@@ -44,7 +48,12 @@ type awsCloudwatchMetadata struct {
 	metricStat           string
 	metricStatPeriod     int64
 
-	awsRegion string
+	// defaultMetricValue is returned when the query has no datapoints, instead of an error
+	defaultMetricValue    float64
+	hasDefaultMetricValue bool
+
+	awsRegion   string
+	awsEndpoint string
 
 	awsAuthorization awsAuthorizationMetadata
 
@@ -54,8 +63,8 @@ type awsCloudwatchMetadata struct {
 var cloudwatchLog = logf.Log.WithName("aws_cloudwatch_scaler")
 
 // NewAwsCloudwatchScaler creates a new awsCloudwatchScaler
-func NewAwsCloudwatchScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
-	meta, err := parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams)
+func NewAwsCloudwatchScaler(resolvedEnv, metadata, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (Scaler, error) {
+	meta, err := parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams, awsWebIdentity)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing Cloudwatch metadata: %s", err)
 	}
@@ -65,7 +74,7 @@ func NewAwsCloudwatchScaler(resolvedEnv, metadata, authParams map[string]string)
 	}, nil
 }
 
-func parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams map[string]string) (*awsCloudwatchMetadata, error) {
+func parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (*awsCloudwatchMetadata, error) {
 	meta := awsCloudwatchMetadata{}
 	meta.metricCollectionTime = defaultMetricCollectionTime
 	meta.metricStat = defaultMetricStat
@@ -77,24 +86,34 @@ func parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams map[string]str
 		return nil, fmt.Errorf("Namespace not given")
 	}
 
+	meta.expression = metadata["expression"]
+
+	// the metric is optional in the expression mode, eg. for SEARCH expressions
 	if val, ok := metadata["metricName"]; ok && val != "" {
 		meta.metricsName = val
-	} else {
+	} else if meta.expression == "" {
 		return nil, fmt.Errorf("Metric Name not given")
 	}
 
 	if val, ok := metadata["dimensionName"]; ok && val != "" {
-		meta.dimensionName = val
-	} else {
+		meta.dimensionName = parseCloudwatchDimensionList(val)
+	} else if meta.expression == "" {
 		return nil, fmt.Errorf("Dimension Name not given")
 	}
 
 	if val, ok := metadata["dimensionValue"]; ok && val != "" {
-		meta.dimensionValue = val
-	} else {
+		meta.dimensionValue = parseCloudwatchDimensionList(val)
+	} else if meta.expression == "" {
 		return nil, fmt.Errorf("Dimension Value not given")
 	}
 
+	if len(meta.dimensionName) != len(meta.dimensionValue) {
+		return nil, fmt.Errorf("%d dimension names given for %d dimension values", len(meta.dimensionName), len(meta.dimensionValue))
+	}
+	if len(meta.dimensionName) > 0 && meta.metricsName == "" {
+		return nil, fmt.Errorf("dimensions given without Metric Name")
+	}
+
 	if val, ok := metadata["targetMetricValue"]; ok && val != "" {
 		targetMetricValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
@@ -139,13 +158,24 @@ func parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams map[string]str
 		}
 	}
 
+	if val, ok := metadata["defaultMetricValue"]; ok && val != "" {
+		defaultMetricValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing defaultMetricValue: %s", err)
+		}
+		meta.defaultMetricValue = defaultMetricValue
+		meta.hasDefaultMetricValue = true
+	}
+
 	if val, ok := metadata["awsRegion"]; ok && val != "" {
 		meta.awsRegion = val
 	} else {
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
-	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv)
+	meta.awsEndpoint = metadata["awsEndpoint"]
+
+	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv, awsWebIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +185,15 @@ func parseAwsCloudwatchMetadata(metadata, resolvedEnv, authParams map[string]str
 	return &meta, nil
 }
 
+// parseCloudwatchDimensionList splits the semicolon-separated dimension names or values
+func parseCloudwatchDimensionList(val string) []string {
+	list := strings.Split(val, ";")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+	return list
+}
+
 func (c *awsCloudwatchScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	metricValue, err := c.GetCloudwatchMetrics()
 
@@ -165,7 +204,7 @@ func (c *awsCloudwatchScaler) GetMetrics(ctx context.Context, metricName string,
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(metricValue), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(metricValue*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -173,9 +212,17 @@ func (c *awsCloudwatchScaler) GetMetrics(ctx context.Context, metricName string,
 }
 
 func (c *awsCloudwatchScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetMetricValue := resource.NewQuantity(int64(c.metadata.targetMetricValue), resource.DecimalSI)
-	externalMetric := &v2beta1.ExternalMetricSource{MetricName: fmt.Sprintf("%s-%s-%s", strings.ReplaceAll(c.metadata.namespace, "/", "-"),
-		c.metadata.dimensionName, c.metadata.dimensionValue),
+	targetMetricValue := resource.NewMilliQuantity(int64(c.metadata.targetMetricValue*1000), resource.DecimalSI)
+	metricNameParts := []string{sanitizeMetricName(c.metadata.namespace)}
+	for i := range c.metadata.dimensionName {
+		metricNameParts = append(metricNameParts, sanitizeMetricName(c.metadata.dimensionName[i]), sanitizeMetricName(c.metadata.dimensionValue[i]))
+	}
+	if c.metadata.expression != "" {
+		// the expression can't be part of the name, its hash tells apart the triggers of the same namespace
+		expressionHash := sha256.Sum256([]byte(c.metadata.expression))
+		metricNameParts = append(metricNameParts, "expression", hex.EncodeToString(expressionHash[:])[:8])
+	}
+	externalMetric := &v2beta1.ExternalMetricSource{MetricName: strings.Join(metricNameParts, "-"),
 		TargetAverageValue: targetMetricValue}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta1.MetricSpec{metricSpec}
@@ -196,51 +243,17 @@ func (c *awsCloudwatchScaler) Close() error {
 }
 
 func (c *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(c.metadata.awsRegion),
-	}))
-
-	var cloudwatchClient *cloudwatch.CloudWatch
-	if c.metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(c.metadata.awsAuthorization.awsAccessKeyID, c.metadata.awsAuthorization.awsSecretAccessKey, "")
-
-		if c.metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, c.metadata.awsAuthorization.awsRoleArn)
-		}
-
-		cloudwatchClient = cloudwatch.New(sess, &aws.Config{
-			Region:      aws.String(c.metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		cloudwatchClient = cloudwatch.New(sess, &aws.Config{
-			Region: aws.String(c.metadata.awsRegion),
-		})
+	sess, config, err := getAwsSession(c.metadata.awsRegion, c.metadata.awsEndpoint, c.metadata.awsAuthorization)
+	if err != nil {
+		return -1, err
 	}
 
+	cloudwatchClient := cloudwatch.New(sess, config)
+
 	input := cloudwatch.GetMetricDataInput{
-		StartTime: aws.Time(time.Now().Add(time.Second * -1 * time.Duration(c.metadata.metricCollectionTime))),
-		EndTime:   aws.Time(time.Now()),
-		MetricDataQueries: []*cloudwatch.MetricDataQuery{
-			{
-				Id: aws.String("c1"),
-				MetricStat: &cloudwatch.MetricStat{
-					Metric: &cloudwatch.Metric{
-						Namespace: aws.String(c.metadata.namespace),
-						Dimensions: []*cloudwatch.Dimension{
-							{
-								Name:  aws.String(c.metadata.dimensionName),
-								Value: aws.String(c.metadata.dimensionValue),
-							},
-						},
-						MetricName: aws.String(c.metadata.metricsName),
-					},
-					Period: aws.Int64(c.metadata.metricStatPeriod),
-					Stat:   aws.String(c.metadata.metricStat),
-				},
-				ReturnData: aws.Bool(true),
-			},
-		},
+		StartTime:         aws.Time(time.Now().Add(time.Second * -1 * time.Duration(c.metadata.metricCollectionTime))),
+		EndTime:           aws.Time(time.Now()),
+		MetricDataQueries: c.getMetricDataQueries(),
 	}
 
 	output, err := cloudwatchClient.GetMetricData(&input)
@@ -251,12 +264,59 @@ func (c *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
 	}
 
 	cloudwatchLog.V(1).Info("Received Metric Data", "data", output)
-	var metricValue float64
-	if output.MetricDataResults[0].Values != nil {
-		metricValue = *output.MetricDataResults[0].Values[0]
-	} else {
-		return -1, fmt.Errorf("Metric Data not received")
+
+	resultID := cloudwatchMetricQueryID
+	if c.metadata.expression != "" {
+		resultID = cloudwatchExpressionQueryID
+	}
+	for _, result := range output.MetricDataResults {
+		if aws.StringValue(result.Id) == resultID && len(result.Values) > 0 {
+			return aws.Float64Value(result.Values[0]), nil
+		}
+	}
+
+	if c.metadata.hasDefaultMetricValue {
+		return c.metadata.defaultMetricValue, nil
+	}
+	return -1, fmt.Errorf("Metric Data not received")
+}
+
+// getMetricDataQueries returns the metric query, which only feeds the expression in the expression mode,
+// and the expression query
+func (c *awsCloudwatchScaler) getMetricDataQueries() []*cloudwatch.MetricDataQuery {
+	queries := []*cloudwatch.MetricDataQuery{}
+
+	if c.metadata.metricsName != "" {
+		dimensions := []*cloudwatch.Dimension{}
+		for i := range c.metadata.dimensionName {
+			dimensions = append(dimensions, &cloudwatch.Dimension{
+				Name:  aws.String(c.metadata.dimensionName[i]),
+				Value: aws.String(c.metadata.dimensionValue[i]),
+			})
+		}
+
+		queries = append(queries, &cloudwatch.MetricDataQuery{
+			Id: aws.String(cloudwatchMetricQueryID),
+			MetricStat: &cloudwatch.MetricStat{
+				Metric: &cloudwatch.Metric{
+					Namespace:  aws.String(c.metadata.namespace),
+					Dimensions: dimensions,
+					MetricName: aws.String(c.metadata.metricsName),
+				},
+				Period: aws.Int64(c.metadata.metricStatPeriod),
+				Stat:   aws.String(c.metadata.metricStat),
+			},
+			ReturnData: aws.Bool(c.metadata.expression == ""),
+		})
+	}
+
+	if c.metadata.expression != "" {
+		queries = append(queries, &cloudwatch.MetricDataQuery{
+			Id:         aws.String(cloudwatchExpressionQueryID),
+			Expression: aws.String(c.metadata.expression),
+			ReturnData: aws.Bool(true),
+		})
 	}
 
-	return metricValue, nil
+	return queries
 }
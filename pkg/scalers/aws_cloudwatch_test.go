@@ -1,6 +1,10 @@
 package scalers
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -159,11 +163,77 @@ var testAWSCloudwatchMetadata = []parseAWSCloudwatchMetadataTestData{
 		map[string]string{},
 		false,
 		"with AWS Role assigned on KEDA operator itself"},
+	{map[string]string{
+		"namespace":         "AWS/ApplicationELB",
+		"dimensionName":     "LoadBalancer;TargetGroup",
+		"dimensionValue":    "app/keda/1234; targetgroup/keda/5678",
+		"metricName":        "RequestCountPerTarget",
+		"targetMetricValue": "100",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		false,
+		"multiple dimensions"},
+	{map[string]string{
+		"namespace":         "AWS/ApplicationELB",
+		"dimensionName":     "LoadBalancer;TargetGroup",
+		"dimensionValue":    "app/keda/1234",
+		"metricName":        "RequestCountPerTarget",
+		"targetMetricValue": "100",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"more dimension names than values"},
+	{map[string]string{
+		"namespace":         "AWS/SQS",
+		"dimensionName":     "QueueName",
+		"dimensionValue":    "keda",
+		"metricName":        "ApproximateNumberOfMessagesVisible",
+		"expression":        "SUM(METRICS())/2",
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		false,
+		"expression over the metric"},
+	{map[string]string{
+		"namespace":         "AWS/SQS",
+		"expression":        `SUM(SEARCH('{AWS/SQS,QueueName} MetricName="ApproximateNumberOfMessagesVisible"', 'Maximum', 300))`,
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		false,
+		"search expression without metric"},
+	{map[string]string{
+		"namespace":         "AWS/SQS",
+		"dimensionName":     "QueueName",
+		"dimensionValue":    "keda",
+		"expression":        "SUM(METRICS())",
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"expression with dimensions but no metricName"},
+	{map[string]string{
+		"namespace":          "AWS/SQS",
+		"dimensionName":      "QueueName",
+		"dimensionValue":     "keda",
+		"metricName":         "ApproximateNumberOfMessagesVisible",
+		"targetMetricValue":  "2",
+		"minMetricValue":     "0",
+		"defaultMetricValue": "zero",
+		"awsRegion":          "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"malformed defaultMetricValue"},
 }
 
 func TestCloudwatchParseMetadata(t *testing.T) {
 	for _, testData := range testAWSCloudwatchMetadata {
-		_, err := parseAwsCloudwatchMetadata(testData.metadata, testAWSCloudwatchResolvedEnv, testData.authParams)
+		_, err := parseAwsCloudwatchMetadata(testData.metadata, testAWSCloudwatchResolvedEnv, testData.authParams, nil)
 		if err != nil && !testData.isError {
 			t.Errorf("%s: Expected success but got error %s", testData.comment, err)
 		}
@@ -172,3 +242,121 @@ func TestCloudwatchParseMetadata(t *testing.T) {
 		}
 	}
 }
+
+type cloudwatchMetricNameTestData struct {
+	metadata   map[string]string
+	metricName string
+}
+
+var testAWSCloudwatchMetricNames = []cloudwatchMetricNameTestData{
+	{map[string]string{"namespace": "AWS/SQS", "dimensionName": "QueueName", "dimensionValue": "keda", "metricName": "ApproximateNumberOfMessagesVisible", "targetMetricValue": "2", "minMetricValue": "0", "awsRegion": "eu-west-1"}, "AWS-SQS-QueueName-keda"},
+	{map[string]string{"namespace": "AWS/ApplicationELB", "dimensionName": "LoadBalancer;TargetGroup", "dimensionValue": "app/keda-alb/50dc6c495c0c9188;targetgroup/keda-tg/73e2d6bc24d8a067", "metricName": "RequestCountPerTarget", "targetMetricValue": "2", "minMetricValue": "0", "awsRegion": "eu-west-1"}, "AWS-ApplicationELB-LoadBalancer-app-keda-alb-50dc6c495c0c9188-TargetGroup-targetgroup-keda-tg-73e2d6bc24d8a067"},
+	{map[string]string{"namespace": "AWS/SQS", "expression": "SUM(METRICS())", "targetMetricValue": "2", "minMetricValue": "0", "awsRegion": "eu-west-1"}, "AWS-SQS-expression-26b9bf55"},
+	{map[string]string{"namespace": "AWS/SQS", "expression": "SUM(METRICS())/2", "targetMetricValue": "2", "minMetricValue": "0", "awsRegion": "eu-west-1"}, "AWS-SQS-expression-99fa5c5b"},
+}
+
+func TestCloudwatchGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range testAWSCloudwatchMetricNames {
+		scaler, err := NewAwsCloudwatchScaler(testAWSCloudwatchResolvedEnv, testData.metadata, testAWSAuthentication, nil)
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+
+		metricName := scaler.GetMetricSpecForScaling()[0].External.MetricName
+		if metricName != testData.metricName {
+			t.Errorf("Expected metric %s but got %s", testData.metricName, metricName)
+		}
+	}
+}
+
+// cloudwatchMetricDataResponse answers GetMetricData with the values of the query, no values when empty
+func cloudwatchMetricDataResponse(queryID string, values ...float64) string {
+	members := ""
+	for _, value := range values {
+		members += fmt.Sprintf("<member>%g</member>", value)
+	}
+	return fmt.Sprintf(`<GetMetricDataResponse xmlns="http://monitoring.amazonaws.com/doc/2010-08-01/">
+  <GetMetricDataResult>
+    <MetricDataResults>
+      <member><Id>%s</Id><Label>%s</Label><StatusCode>Complete</StatusCode><Timestamps/><Values>%s</Values></member>
+    </MetricDataResults>
+  </GetMetricDataResult>
+  <ResponseMetadata><RequestId>keda</RequestId></ResponseMetadata>
+</GetMetricDataResponse>`, queryID, queryID, members)
+}
+
+func TestCloudwatchGetMetrics(t *testing.T) {
+	// the stand-in only has datapoints for the SUM(METRICS())/2 expression
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("Action") != "GetMetricData" {
+			t.Errorf("Unexpected request %v", r.Form)
+		}
+
+		// the metric with both dimensions feeds the expression, only the expression is returned
+		metric := "MetricDataQueries.member.1."
+		if r.Form.Get(metric+"Id") != "c1" || r.Form.Get(metric+"ReturnData") != "false" ||
+			r.Form.Get(metric+"MetricStat.Metric.Dimensions.member.2.Name") != "TargetGroup" ||
+			r.Form.Get(metric+"MetricStat.Metric.Dimensions.member.2.Value") != "tg" {
+			t.Errorf("Unexpected metric query %v", r.Form)
+		}
+		expression := "MetricDataQueries.member.2."
+		if r.Form.Get(expression+"Id") != "e1" {
+			t.Errorf("Unexpected expression query %v", r.Form)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		if r.Form.Get(expression+"Expression") == "SUM(METRICS())/2" {
+			fmt.Fprint(w, cloudwatchMetricDataResponse("e1", 2.5, 4))
+			return
+		}
+		fmt.Fprint(w, cloudwatchMetricDataResponse("e1"))
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{
+		"namespace":         "AWS/ApplicationELB",
+		"dimensionName":     "LoadBalancer;TargetGroup",
+		"dimensionValue":    "app;tg",
+		"metricName":        "RequestCountPerTarget",
+		"expression":        "SUM(METRICS())/2",
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1",
+		"awsEndpoint":       server.URL,
+	}
+	scaler, err := NewAwsCloudwatchScaler(testAWSCloudwatchResolvedEnv, metadata, testAWSAuthentication, nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "AWS-ApplicationELB-LoadBalancer-app-TargetGroup-tg-expression", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if metrics[0].Value.MilliValue() != 2500 {
+		t.Errorf("Expected value 2.5 but got %s", metrics[0].Value.String())
+	}
+
+	// no datapoints is an error unless a default value is given
+	metadata["expression"] = "SUM(METRICS())/3"
+	scaler, err = NewAwsCloudwatchScaler(testAWSCloudwatchResolvedEnv, metadata, testAWSAuthentication, nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error without datapoints but got success")
+	}
+
+	metadata["defaultMetricValue"] = "0"
+	scaler, err = NewAwsCloudwatchScaler(testAWSCloudwatchResolvedEnv, metadata, testAWSAuthentication, nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	isActive, err := scaler.IsActive(context.TODO())
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if isActive {
+		t.Error("Expected inactive but got active")
+	}
+}
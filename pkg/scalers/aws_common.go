@@ -0,0 +1,158 @@
+package scalers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+const (
+	awsRoleSessionName = "keda"
+	// awsCredentialsExpiryWindow is how long before their expiration the assumed role credentials are refreshed
+	awsCredentialsExpiryWindow = time.Minute
+	// awsCredentialsIdleTimeout is how long the credentials of an authorization no scaler uses anymore are kept
+	awsCredentialsIdleTimeout = 15 * time.Minute
+)
+
+// awsCredentialsCache shares the credentials of the scalers using the same region and authorization,
+// so the roles are assumed once per expiration of their credentials instead of on every poll.
+// Scalers are rebuilt on every poll, the entries are dropped once no scaler asked for them for awsCredentialsIdleTimeout.
+type awsCredentialsCache struct {
+	mutex       sync.Mutex
+	credentials map[string]*awsCachedCredentials
+}
+
+type awsCachedCredentials struct {
+	credentials *credentials.Credentials
+	lastUsed    time.Time
+}
+
+var awsCredentials = &awsCredentialsCache{credentials: map[string]*awsCachedCredentials{}}
+
+// get returns the cached credentials for the region and authorization, building them on first use
+func (c *awsCredentialsCache) get(sess *session.Session, awsRegion string, awsAuthorization awsAuthorizationMetadata) *credentials.Credentials {
+	key := awsCredentialsCacheKey(awsRegion, awsAuthorization)
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.evictIdle(now)
+
+	entry, ok := c.credentials[key]
+	if !ok {
+		entry = &awsCachedCredentials{credentials: getAwsCredentials(sess, awsAuthorization)}
+		c.credentials[key] = entry
+	}
+	entry.lastUsed = now
+	return entry.credentials
+}
+
+// evictIdle drops the credentials unused since awsCredentialsIdleTimeout, the mutex must be held
+func (c *awsCredentialsCache) evictIdle(now time.Time) {
+	for key, entry := range c.credentials {
+		if now.Sub(entry.lastUsed) > awsCredentialsIdleTimeout {
+			delete(c.credentials, key)
+		}
+	}
+}
+
+// awsCredentialsCacheKey hashes the authorization so the secrets aren't kept as map keys
+func awsCredentialsCacheKey(awsRegion string, awsAuthorization awsAuthorizationMetadata) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		awsRegion,
+		awsAuthorization.awsAccessKeyID,
+		awsAuthorization.awsSecretAccessKey,
+		awsAuthorization.awsSessionToken,
+		webIdentityKey(awsAuthorization.awsWebIdentity),
+		awsAuthorization.awsRoleArn,
+		strings.Join(awsAuthorization.awsRoleChain, ","),
+		awsAuthorization.awsExternalID,
+	}, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+func webIdentityKey(awsWebIdentity *AwsWebIdentity) string {
+	if awsWebIdentity == nil {
+		return ""
+	}
+	return awsWebIdentity.String()
+}
+
+// getAwsCredentials returns static credentials, or the credentials of the last role of the chain
+// starting at awsRoleArn, each role being assumed with the credentials of the previous one.
+// awsRoleArn is assumed with tokens of the service account of the scale target for the aws-eks pod identity, with the credentials of the operator otherwise.
+func getAwsCredentials(sess *session.Session, awsAuthorization awsAuthorizationMetadata) *credentials.Credentials {
+	if awsAuthorization.awsRoleArn == "" {
+		return credentials.NewStaticCredentials(awsAuthorization.awsAccessKeyID, awsAuthorization.awsSecretAccessKey, awsAuthorization.awsSessionToken)
+	}
+
+	roles := append([]string{awsAuthorization.awsRoleArn}, awsAuthorization.awsRoleChain...)
+	lastRole := len(roles) - 1
+
+	var creds *credentials.Credentials
+	for i, roleArn := range roles {
+		externalID := ""
+		if i == lastRole {
+			externalID = awsAuthorization.awsExternalID
+		}
+
+		if i == 0 {
+			if awsAuthorization.awsWebIdentity != nil {
+				provider := stscreds.NewWebIdentityRoleProviderWithToken(sts.New(sess), roleArn, awsRoleSessionName, awsAuthorization.awsWebIdentity)
+				provider.ExpiryWindow = awsCredentialsExpiryWindow
+				creds = credentials.NewCredentials(provider)
+			} else {
+				creds = stscreds.NewCredentials(sess, roleArn, assumeRoleOptions(externalID))
+			}
+			continue
+		}
+
+		hopSession := sess.Copy(&aws.Config{Credentials: creds})
+		creds = stscreds.NewCredentials(hopSession, roleArn, assumeRoleOptions(externalID))
+	}
+
+	return creds
+}
+
+func assumeRoleOptions(externalID string) func(*stscreds.AssumeRoleProvider) {
+	return func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = awsRoleSessionName
+		p.ExpiryWindow = awsCredentialsExpiryWindow
+		if externalID != "" {
+			p.ExternalID = aws.String(externalID)
+		}
+	}
+}
+
+// getAwsSession returns the session and the client config for the region, custom endpoint and credentials of an AWS scaler.
+// The endpoint is only set on the client config, so the roles are still assumed through the STS endpoint of the region.
+func getAwsSession(awsRegion, awsEndpoint string, awsAuthorization awsAuthorizationMetadata) (*session.Session, *aws.Config, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(awsRegion),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &aws.Config{
+		Region: aws.String(awsRegion),
+	}
+	if awsEndpoint != "" {
+		config.Endpoint = aws.String(awsEndpoint)
+	}
+
+	if awsAuthorization.podIdentityOwner {
+		config.Credentials = awsCredentials.get(sess, awsRegion, awsAuthorization)
+	}
+
+	return sess, config, nil
+}
@@ -0,0 +1,129 @@
+package scalers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+type awsSessionTestData struct {
+	awsEndpoint      string
+	awsAuthorization awsAuthorizationMetadata
+	expectedKeyID    string
+	comment          string
+}
+
+var testAwsSessions = []awsSessionTestData{
+	{"", awsAuthorizationMetadata{podIdentityOwner: true, awsAccessKeyID: "id", awsSecretAccessKey: "secret"}, "id", "default endpoint, static credentials"},
+	{"http://localstack:4566", awsAuthorizationMetadata{podIdentityOwner: true, awsAccessKeyID: "id", awsSecretAccessKey: "secret"}, "id", "custom endpoint, static credentials"},
+	{"https://vpce-0123.sqs.eu-west-1.vpce.amazonaws.com", awsAuthorizationMetadata{podIdentityOwner: false}, "", "custom endpoint, operator identity"},
+}
+
+func TestGetAwsSession(t *testing.T) {
+	for _, testData := range testAwsSessions {
+		sess, config, err := getAwsSession("eu-west-1", testData.awsEndpoint, testData.awsAuthorization)
+		if err != nil {
+			t.Fatalf("%s: expected success but got error %s", testData.comment, err)
+		}
+
+		if aws.StringValue(config.Region) != "eu-west-1" {
+			t.Errorf("%s: expected region eu-west-1 but got %s", testData.comment, aws.StringValue(config.Region))
+		}
+		if aws.StringValue(config.Endpoint) != testData.awsEndpoint {
+			t.Errorf("%s: expected endpoint %s but got %s", testData.comment, testData.awsEndpoint, aws.StringValue(config.Endpoint))
+		}
+		// the session is used by the STS client assuming roles, it keeps the default endpoint
+		if sess.Config.Endpoint != nil {
+			t.Errorf("%s: expected no endpoint on the session but got %s", testData.comment, aws.StringValue(sess.Config.Endpoint))
+		}
+
+		if testData.expectedKeyID == "" {
+			if config.Credentials != nil {
+				t.Errorf("%s: expected the default credentials chain but got static credentials", testData.comment)
+			}
+			continue
+		}
+		creds, err := config.Credentials.Get()
+		if err != nil {
+			t.Errorf("%s: expected credentials but got error %s", testData.comment, err)
+		} else if creds.AccessKeyID != testData.expectedKeyID {
+			t.Errorf("%s: expected access key id %s but got %s", testData.comment, testData.expectedKeyID, creds.AccessKeyID)
+		}
+	}
+}
+
+func TestGetAwsSessionCachesCredentials(t *testing.T) {
+	auth := awsAuthorizationMetadata{
+		podIdentityOwner: true,
+		awsRoleArn:       "arn:aws:iam::111111111111:role/keda",
+		awsRoleChain:     []string{"arn:aws:iam::222222222222:role/queue"},
+		awsExternalID:    "external",
+	}
+
+	_, config, err := getAwsSession("eu-west-1", "", auth)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	_, sameConfig, err := getAwsSession("eu-west-1", "http://localstack:4566", auth)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if config.Credentials == nil || config.Credentials != sameConfig.Credentials {
+		t.Error("Expected the credentials of the role chain to be shared")
+	}
+
+	auth.awsExternalID = "other"
+	_, otherConfig, err := getAwsSession("eu-west-1", "", auth)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if otherConfig.Credentials == config.Credentials {
+		t.Error("Expected new credentials for another external id")
+	}
+
+	auth.awsWebIdentity = NewAwsWebIdentity(nil, "default", "workload")
+	_, workloadConfig, err := getAwsSession("eu-west-1", "", auth)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	auth.awsWebIdentity = NewAwsWebIdentity(nil, "default", "other-workload")
+	_, otherWorkloadConfig, err := getAwsSession("eu-west-1", "", auth)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if workloadConfig.Credentials == otherConfig.Credentials || workloadConfig.Credentials == otherWorkloadConfig.Credentials {
+		t.Error("Expected new credentials for the web identity of another service account")
+	}
+}
+
+func TestAwsCredentialsCacheEvictsIdleCredentials(t *testing.T) {
+	cache := &awsCredentialsCache{credentials: map[string]*awsCachedCredentials{}}
+	sess, _, err := getAwsSession("eu-west-1", "", awsAuthorizationMetadata{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	auth := awsAuthorizationMetadata{podIdentityOwner: true, awsAccessKeyID: "id", awsSecretAccessKey: "secret"}
+	creds := cache.get(sess, "eu-west-1", auth)
+	for key := range cache.credentials {
+		if strings.Contains(key, "secret") {
+			t.Errorf("Expected the cache key to be hashed but got %s", key)
+		}
+	}
+
+	cache.mutex.Lock()
+	cache.evictIdle(time.Now().Add(awsCredentialsIdleTimeout / 2))
+	cache.mutex.Unlock()
+	if cache.get(sess, "eu-west-1", auth) != creds {
+		t.Error("Expected the credentials to be kept while they are used")
+	}
+
+	cache.mutex.Lock()
+	cache.evictIdle(time.Now().Add(awsCredentialsIdleTimeout + time.Minute))
+	cache.mutex.Unlock()
+	if len(cache.credentials) != 0 {
+		t.Errorf("Expected the idle credentials to be evicted but %d are cached", len(cache.credentials))
+	}
+}
@@ -0,0 +1,192 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type awsDynamoDBScaler struct {
+	metadata *awsDynamoDBMetadata
+}
+
+type awsDynamoDBMetadata struct {
+	tableName                 string
+	indexName                 string
+	keyConditionExpression    string
+	filterExpression          string
+	expressionAttributeNames  map[string]*string
+	expressionAttributeValues map[string]*dynamodb.AttributeValue
+	targetValue               float64
+	awsRegion                 string
+	awsEndpoint               string
+	awsAuthorization          awsAuthorizationMetadata
+}
+
+var dynamoDBLog = logf.Log.WithName("aws_dynamodb_scaler")
+
+// NewAwsDynamoDBScaler creates a new awsDynamoDBScaler
+func NewAwsDynamoDBScaler(resolvedEnv, metadata, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (Scaler, error) {
+	meta, err := parseAwsDynamoDBMetadata(metadata, resolvedEnv, authParams, awsWebIdentity)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing DynamoDB metadata: %s", err)
+	}
+
+	return &awsDynamoDBScaler{
+		metadata: meta,
+	}, nil
+}
+
+func parseAwsDynamoDBMetadata(metadata, resolvedEnv, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (*awsDynamoDBMetadata, error) {
+	meta := awsDynamoDBMetadata{}
+
+	if val, ok := metadata["tableName"]; ok && val != "" {
+		meta.tableName = val
+	} else {
+		return nil, fmt.Errorf("no tableName given")
+	}
+
+	// the query runs against the table unless a global secondary index is given
+	meta.indexName = metadata["indexName"]
+
+	if val, ok := metadata["keyConditionExpression"]; ok && val != "" {
+		meta.keyConditionExpression = val
+	} else {
+		return nil, fmt.Errorf("no keyConditionExpression given")
+	}
+
+	meta.filterExpression = metadata["filterExpression"]
+
+	if val, ok := metadata["expressionAttributeNames"]; ok && val != "" {
+		names := map[string]string{}
+		if err := json.Unmarshal([]byte(val), &names); err != nil {
+			return nil, fmt.Errorf("error parsing expressionAttributeNames: %s", err)
+		}
+		meta.expressionAttributeNames = aws.StringMap(names)
+	}
+
+	if val, ok := metadata["expressionAttributeValues"]; ok && val != "" {
+		// the values use the DynamoDB JSON format, eg. {":status": {"S": "pending"}}
+		values := map[string]*dynamodb.AttributeValue{}
+		if err := json.Unmarshal([]byte(val), &values); err != nil {
+			return nil, fmt.Errorf("error parsing expressionAttributeValues: %s", err)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("empty expressionAttributeValues given")
+		}
+		meta.expressionAttributeValues = values
+	} else {
+		return nil, fmt.Errorf("no expressionAttributeValues given")
+	}
+
+	if val, ok := metadata["targetValue"]; ok && val != "" {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetValue: %s", err)
+		}
+		meta.targetValue = targetValue
+	} else {
+		return nil, fmt.Errorf("no targetValue given")
+	}
+
+	if val, ok := metadata["awsRegion"]; ok && val != "" {
+		meta.awsRegion = val
+	} else {
+		return nil, fmt.Errorf("no awsRegion given")
+	}
+
+	meta.awsEndpoint = metadata["awsEndpoint"]
+
+	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv, awsWebIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.awsAuthorization = auth
+
+	return &meta, nil
+}
+
+// IsActive determines if we need to scale from zero
+func (s *awsDynamoDBScaler) IsActive(ctx context.Context) (bool, error) {
+	count, err := s.GetQueryCount(ctx)
+	if err != nil {
+		dynamoDBLog.Error(err, "Error getting item count")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *awsDynamoDBScaler) Close() error {
+	return nil
+}
+
+func (s *awsDynamoDBScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	targetValueQty := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
+	externalMetric := &v2beta1.ExternalMetricSource{MetricName: fmt.Sprintf("%s-%s", "AWS-DynamoDB", s.metadata.tableName),
+		TargetAverageValue: targetValueQty}
+	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2beta1.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *awsDynamoDBScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	count, err := s.GetQueryCount(ctx)
+	if err != nil {
+		dynamoDBLog.Error(err, "Error getting item count")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(count*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// GetQueryCount returns the number of items matching the query, summed across the result pages
+func (s *awsDynamoDBScaler) GetQueryCount(ctx context.Context) (int64, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(s.metadata.tableName),
+		KeyConditionExpression:    aws.String(s.metadata.keyConditionExpression),
+		ExpressionAttributeNames:  s.metadata.expressionAttributeNames,
+		ExpressionAttributeValues: s.metadata.expressionAttributeValues,
+		Select:                    aws.String(dynamodb.SelectCount),
+	}
+	if s.metadata.indexName != "" {
+		input.IndexName = aws.String(s.metadata.indexName)
+	}
+	if s.metadata.filterExpression != "" {
+		input.FilterExpression = aws.String(s.metadata.filterExpression)
+	}
+
+	sess, config, err := getAwsSession(s.metadata.awsRegion, s.metadata.awsEndpoint, s.metadata.awsAuthorization)
+	if err != nil {
+		return -1, err
+	}
+	dynamoDBClient := dynamodb.New(sess, config)
+
+	count := int64(0)
+	err = dynamoDBClient.QueryPagesWithContext(ctx, input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		count += aws.Int64Value(page.Count)
+		return true
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return count, nil
+}
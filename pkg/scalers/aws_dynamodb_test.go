@@ -0,0 +1,202 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testAWSDynamoDBAuthentication = map[string]string{
+	"awsAccessKeyId":     "none",
+	"awsSecretAccessKey": "none",
+}
+
+type parseAWSDynamoDBMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+var testAWSDynamoDBMetadata = []parseAWSDynamoDBMetadataTestData{
+	{map[string]string{},
+		testAWSDynamoDBAuthentication,
+		true,
+		"metadata empty"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "#status = :status",
+		"expressionAttributeNames":  `{"#status": "status"}`,
+		"expressionAttributeValues": `{":status": {"S": "pending"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		false,
+		"properly formed table query"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"indexName":                 "status-index",
+		"keyConditionExpression":    "queue = :queue",
+		"filterExpression":          "attempts < :attempts",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}, ":attempts": {"N": "3"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1",
+		"awsEndpoint":               "http://localhost:8000"},
+		testAWSDynamoDBAuthentication,
+		false,
+		"properly formed index query with filter and endpoint"},
+	{map[string]string{
+		"keyConditionExpression":    "queue = :queue",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"missing tableName"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"missing keyConditionExpression"},
+	{map[string]string{
+		"tableName":              "tasks",
+		"keyConditionExpression": "queue = :queue",
+		"targetValue":            "10",
+		"awsRegion":              "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"missing expressionAttributeValues"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "queue = :queue",
+		"expressionAttributeValues": `{":queue": "ingest"}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"expressionAttributeValues not in the DynamoDB JSON format"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "#queue = :queue",
+		"expressionAttributeNames":  `["queue"]`,
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"malformed expressionAttributeNames"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "queue = :queue",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"targetValue":               "ten",
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"malformed targetValue"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "queue = :queue",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"awsRegion":                 "eu-west-1"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"missing targetValue"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "queue = :queue",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"targetValue":               "10"},
+		testAWSDynamoDBAuthentication,
+		true,
+		"missing awsRegion"},
+	{map[string]string{
+		"tableName":                 "tasks",
+		"keyConditionExpression":    "queue = :queue",
+		"expressionAttributeValues": `{":queue": {"S": "ingest"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1"},
+		map[string]string{},
+		true,
+		"missing credentials"},
+}
+
+func TestDynamoDBParseMetadata(t *testing.T) {
+	for _, testData := range testAWSDynamoDBMetadata {
+		_, err := parseAwsDynamoDBMetadata(testData.metadata, map[string]string{}, testData.authParams, nil)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success because %s got error, %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error because %s but got success", testData.comment)
+		}
+	}
+}
+
+func TestDynamoDBGetQueryCount(t *testing.T) {
+	// stands in for DynamoDB, the query results come in two pages
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "DynamoDB_20120810.Query" {
+			t.Errorf("Unexpected operation %s", target)
+		}
+
+		var input struct {
+			TableName                 string
+			IndexName                 string
+			Select                    string
+			KeyConditionExpression    string
+			ExpressionAttributeValues map[string]map[string]string
+			ExclusiveStartKey         map[string]map[string]string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Error("Expected a JSON body but got error", err)
+		}
+		if input.TableName != "tasks" || input.IndexName != "status-index" || input.Select != "COUNT" {
+			t.Errorf("Unexpected query %+v", input)
+		}
+		if input.ExpressionAttributeValues[":status"]["S"] != "pending" {
+			t.Errorf("Unexpected expression attribute values %v", input.ExpressionAttributeValues)
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if input.ExclusiveStartKey == nil {
+			fmt.Fprint(w, `{"Count": 3, "ScannedCount": 5, "LastEvaluatedKey": {"id": {"S": "task-5"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"Count": 2, "ScannedCount": 2}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{
+		"tableName":                 "tasks",
+		"indexName":                 "status-index",
+		"keyConditionExpression":    "#status = :status",
+		"expressionAttributeNames":  `{"#status": "status"}`,
+		"expressionAttributeValues": `{":status": {"S": "pending"}}`,
+		"targetValue":               "10",
+		"awsRegion":                 "eu-west-1",
+		"awsEndpoint":               server.URL,
+	}
+	scaler, err := NewAwsDynamoDBScaler(map[string]string{}, metadata, testAWSDynamoDBAuthentication, nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "AWS-DynamoDB-tasks", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if metrics[0].Value.Value() != 5 {
+		t.Errorf("Expected count 5 but got %d", metrics[0].Value.Value())
+	}
+
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "AWS-DynamoDB-tasks" {
+		t.Errorf("Expected metric AWS-DynamoDB-tasks but got %s", name)
+	}
+}
@@ -1,15 +1,72 @@
 package scalers
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
 
 const (
 	awsAccessKeyIDEnvVar     = "AWS_ACCESS_KEY_ID"
 	awsSecretAccessKeyEnvVar = "AWS_SECRET_ACCESS_KEY"
 	awsSessionTokenEnvVar    = "AWS_SESSION_TOKEN"
+
+	// awsWebIdentityTokenAudience is the audience STS expects in the web identity tokens of IAM roles for service accounts
+	awsWebIdentityTokenAudience = "sts.amazonaws.com"
+	// awsWebIdentityTokenExpiration is the shortest expiration the TokenRequest API accepts,
+	// the token is only used to assume the role and the credentials are refreshed with a new token
+	awsWebIdentityTokenExpiration = 10 * time.Minute
 )
 
+// AwsWebIdentity mints tokens for the service account of the scale target through the TokenRequest API,
+// the AWS scalers assume awsRoleArn with these tokens as IAM roles for service accounts do for the workload
+type AwsWebIdentity struct {
+	serviceAccounts    corev1client.ServiceAccountsGetter
+	namespace          string
+	serviceAccountName string
+}
+
+// NewAwsWebIdentity creates the web identity of a service account
+func NewAwsWebIdentity(serviceAccounts corev1client.ServiceAccountsGetter, namespace, serviceAccountName string) *AwsWebIdentity {
+	return &AwsWebIdentity{
+		serviceAccounts:    serviceAccounts,
+		namespace:          namespace,
+		serviceAccountName: serviceAccountName,
+	}
+}
+
+// FetchToken implements stscreds.TokenFetcher, it is called every time the assumed role credentials are refreshed
+func (w *AwsWebIdentity) FetchToken(credentials.Context) ([]byte, error) {
+	expirationSeconds := int64(awsWebIdentityTokenExpiration / time.Second)
+	tokenRequest, err := w.serviceAccounts.ServiceAccounts(w.namespace).CreateToken(w.serviceAccountName, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{awsWebIdentityTokenAudience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error requesting a token for service account %s/%s: %s", w.namespace, w.serviceAccountName, err)
+	}
+	return []byte(tokenRequest.Status.Token), nil
+}
+
+func (w *AwsWebIdentity) String() string {
+	return w.namespace + "/" + w.serviceAccountName
+}
+
 type awsAuthorizationMetadata struct {
 	awsRoleArn string
+	// awsRoleChain lists the roles assumed one after the other once awsRoleArn is assumed
+	awsRoleChain []string
+	// awsExternalID is passed when assuming the last role of the chain
+	awsExternalID string
+	// awsWebIdentity is set for the aws-eks pod identity, awsRoleArn is then assumed
+	// with AssumeRoleWithWebIdentity and a token of the service account of the scale target
+	awsWebIdentity *AwsWebIdentity
 
 	awsAccessKeyID     string
 	awsSecretAccessKey string
@@ -18,15 +75,18 @@ type awsAuthorizationMetadata struct {
 	podIdentityOwner bool
 }
 
-func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string) (awsAuthorizationMetadata, error) {
+func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string, awsWebIdentity *AwsWebIdentity) (awsAuthorizationMetadata, error) {
 	meta := awsAuthorizationMetadata{}
 
 	if metadata["identityOwner"] == "operator" {
 		meta.podIdentityOwner = false
 	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
 		meta.podIdentityOwner = true
+		meta.awsWebIdentity = awsWebIdentity
 		if authParams["awsRoleArn"] != "" {
 			meta.awsRoleArn = authParams["awsRoleArn"]
+		} else if awsWebIdentity != nil {
+			return meta, fmt.Errorf("the web identity of service account %s requires an awsRoleArn", awsWebIdentity)
 		} else if (authParams["awsAccessKeyID"] != "" || authParams["awsAccessKeyId"] != "") && authParams["awsSecretAccessKey"] != "" {
 			meta.awsAccessKeyID = authParams["awsAccessKeyID"]
 			if meta.awsAccessKeyID == "" {
@@ -53,7 +113,46 @@ func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string) (a
 				return meta, fmt.Errorf("'%s' doesn't exist in the deployment environment", keyName)
 			}
 		}
+
+		if err := parseAwsRoleAssumption(authParams, metadata, &meta); err != nil {
+			return meta, err
+		}
 	}
 
 	return meta, nil
 }
+
+// parseAwsRoleAssumption reads the role chain and the external id from the auth params first and then from the metadata
+func parseAwsRoleAssumption(authParams, metadata map[string]string, meta *awsAuthorizationMetadata) error {
+	getParam := func(name string) string {
+		if val := authParams[name]; val != "" {
+			return val
+		}
+		return metadata[name]
+	}
+
+	if val := getParam("awsRoleChain"); val != "" {
+		for _, roleArn := range strings.Split(val, ",") {
+			if roleArn = strings.TrimSpace(roleArn); roleArn != "" {
+				meta.awsRoleChain = append(meta.awsRoleChain, roleArn)
+			}
+		}
+	}
+	meta.awsExternalID = getParam("awsExternalId")
+
+	if meta.awsRoleArn == "" {
+		switch {
+		case len(meta.awsRoleChain) > 0:
+			return fmt.Errorf("awsRoleChain requires an awsRoleArn to start the chain from")
+		case meta.awsExternalID != "":
+			return fmt.Errorf("awsExternalId requires an awsRoleArn")
+		}
+	}
+
+	// AssumeRoleWithWebIdentity doesn't take an external id, it can only be passed further down the chain
+	if meta.awsWebIdentity != nil && meta.awsExternalID != "" && len(meta.awsRoleChain) == 0 {
+		return fmt.Errorf("awsExternalId can't be used when assuming awsRoleArn with a web identity, it is passed to the last role of awsRoleChain")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package scalers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testAwsWebIdentity = NewAwsWebIdentity(fake.NewSimpleClientset().CoreV1(), "default", "workload")
+
+type parseAwsAuthorizationTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	webIdentity *AwsWebIdentity
+	expected    awsAuthorizationMetadata
+	isError     bool
+	comment     string
+}
+
+var testAwsAuthorizations = []parseAwsAuthorizationTestData{
+	{
+		metadata:   map[string]string{},
+		authParams: map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/keda"},
+		expected:   awsAuthorizationMetadata{awsRoleArn: "arn:aws:iam::111111111111:role/keda", podIdentityOwner: true},
+		comment:    "role"},
+	{
+		metadata: map[string]string{"awsRoleChain": "arn:aws:iam::222222222222:role/hop, arn:aws:iam::333333333333:role/queue"},
+		authParams: map[string]string{
+			"awsRoleArn":    "arn:aws:iam::111111111111:role/keda",
+			"awsExternalId": "external",
+		},
+		expected: awsAuthorizationMetadata{
+			awsRoleArn:       "arn:aws:iam::111111111111:role/keda",
+			awsRoleChain:     []string{"arn:aws:iam::222222222222:role/hop", "arn:aws:iam::333333333333:role/queue"},
+			awsExternalID:    "external",
+			podIdentityOwner: true,
+		},
+		comment: "role chain with external id"},
+	{
+		metadata: map[string]string{},
+		authParams: map[string]string{
+			"awsRoleArn":   "arn:aws:iam::111111111111:role/keda",
+			"awsRoleChain": "arn:aws:iam::222222222222:role/queue",
+		},
+		webIdentity: testAwsWebIdentity,
+		expected: awsAuthorizationMetadata{
+			awsRoleArn:       "arn:aws:iam::111111111111:role/keda",
+			awsRoleChain:     []string{"arn:aws:iam::222222222222:role/queue"},
+			awsWebIdentity:   testAwsWebIdentity,
+			podIdentityOwner: true,
+		},
+		comment: "web identity with role chain"},
+	{
+		metadata:   map[string]string{"awsRoleChain": "arn:aws:iam::222222222222:role/queue"},
+		authParams: map[string]string{"awsAccessKeyId": "id", "awsSecretAccessKey": "secret"},
+		isError:    true,
+		comment:    "role chain without awsRoleArn"},
+	{
+		metadata:   map[string]string{},
+		authParams: map[string]string{"awsAccessKeyId": "id", "awsSecretAccessKey": "secret", "awsExternalId": "external"},
+		isError:    true,
+		comment:    "external id without awsRoleArn"},
+	{
+		metadata:    map[string]string{},
+		authParams:  map[string]string{"awsAccessKeyId": "id", "awsSecretAccessKey": "secret"},
+		webIdentity: testAwsWebIdentity,
+		isError:     true,
+		comment:     "web identity without awsRoleArn"},
+	{
+		metadata: map[string]string{},
+		authParams: map[string]string{
+			"awsRoleArn":    "arn:aws:iam::111111111111:role/keda",
+			"awsExternalId": "external",
+		},
+		webIdentity: testAwsWebIdentity,
+		isError:     true,
+		comment:     "external id for the web identity role"},
+	{
+		metadata:   map[string]string{"identityOwner": "operator", "awsRoleChain": "arn:aws:iam::222222222222:role/queue"},
+		authParams: map[string]string{},
+		expected:   awsAuthorizationMetadata{podIdentityOwner: false},
+		comment:    "role chain ignored for the operator identity"},
+	{
+		metadata:    map[string]string{"identityOwner": "operator"},
+		authParams:  map[string]string{},
+		webIdentity: testAwsWebIdentity,
+		expected:    awsAuthorizationMetadata{podIdentityOwner: false},
+		comment:     "web identity ignored for the operator identity"},
+}
+
+func TestGetAwsAuthorization(t *testing.T) {
+	for _, testData := range testAwsAuthorizations {
+		result, err := getAwsAuthorization(testData.authParams, testData.metadata, map[string]string{}, testData.webIdentity)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success because %s got error, %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error because %s but got success", testData.comment)
+		}
+		if !testData.isError && !reflect.DeepEqual(testData.expected, result) {
+			t.Errorf("%s: expected %#v but got %#v", testData.comment, testData.expected, result)
+		}
+	}
+}
+
+func TestAwsWebIdentityFetchToken(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create := action.(k8stesting.CreateAction)
+		if create.GetSubresource() != "token" || create.GetNamespace() != "default" {
+			return true, nil, fmt.Errorf("unexpected %s of serviceaccounts/%s in %s", action.GetVerb(), create.GetSubresource(), create.GetNamespace())
+		}
+		tokenRequest := create.GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+		if !reflect.DeepEqual(tokenRequest.Spec.Audiences, []string{"sts.amazonaws.com"}) {
+			return true, nil, fmt.Errorf("unexpected audiences %v", tokenRequest.Spec.Audiences)
+		}
+		tokenRequest.Status.Token = "workload-token"
+		return true, tokenRequest, nil
+	})
+
+	token, err := NewAwsWebIdentity(clientset.CoreV1(), "default", "workload").FetchToken(nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if string(token) != "workload-token" {
+		t.Errorf("Expected the token of the service account but got %s", token)
+	}
+
+	if _, err := NewAwsWebIdentity(fake.NewSimpleClientset().CoreV1(), "default", "workload").FetchToken(nil); err == nil {
+		t.Error("Expected error because the token request failed but got success")
+	}
+}
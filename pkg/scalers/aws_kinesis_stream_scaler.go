@@ -5,11 +5,6 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/kinesis"
 	v2beta1 "k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -29,17 +24,18 @@ type awsKinesisStreamScaler struct {
 }
 
 type awsKinesisStreamMetadata struct {
-	targetShardCount int
+	targetShardCount float64
 	streamName       string
 	awsRegion        string
+	awsEndpoint      string
 	awsAuthorization awsAuthorizationMetadata
 }
 
 var kinesisStreamLog = logf.Log.WithName("aws_kinesis_stream_scaler")
 
 // NewAwsKinesisStreamScaler creates a new awsKinesisStreamScaler
-func NewAwsKinesisStreamScaler(resolvedEnv, metadata map[string]string, authParams map[string]string) (Scaler, error) {
-	meta, err := parseAwsKinesisStreamMetadata(metadata, resolvedEnv, authParams)
+func NewAwsKinesisStreamScaler(resolvedEnv, metadata, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (Scaler, error) {
+	meta, err := parseAwsKinesisStreamMetadata(metadata, resolvedEnv, authParams, awsWebIdentity)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing Kinesis stream metadata: %s", err)
 	}
@@ -49,12 +45,12 @@ func NewAwsKinesisStreamScaler(resolvedEnv, metadata map[string]string, authPara
 	}, nil
 }
 
-func parseAwsKinesisStreamMetadata(metadata, resolvedEnv, authParams map[string]string) (*awsKinesisStreamMetadata, error) {
+func parseAwsKinesisStreamMetadata(metadata, resolvedEnv, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (*awsKinesisStreamMetadata, error) {
 	meta := awsKinesisStreamMetadata{}
 	meta.targetShardCount = targetShardCountDefault
 
 	if val, ok := metadata["shardCount"]; ok && val != "" {
-		shardCount, err := strconv.Atoi(val)
+		shardCount, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			meta.targetShardCount = targetShardCountDefault
 			kinesisStreamLog.Error(err, "Error parsing Kinesis stream metadata shardCount, using default %n", targetShardCountDefault)
@@ -75,7 +71,9 @@ func parseAwsKinesisStreamMetadata(metadata, resolvedEnv, authParams map[string]
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
-	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv)
+	meta.awsEndpoint = metadata["awsEndpoint"]
+
+	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv, awsWebIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +99,7 @@ func (s *awsKinesisStreamScaler) Close() error {
 }
 
 func (s *awsKinesisStreamScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetShardCountQty := resource.NewQuantity(int64(s.metadata.targetShardCount), resource.DecimalSI)
+	targetShardCountQty := resource.NewMilliQuantity(int64(s.metadata.targetShardCount*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: fmt.Sprintf("%s-%s-%s", "AWS-Kinesis-Stream", awsKinesisStreamMetricName, s.metadata.streamName),
 		TargetAverageValue: targetShardCountQty}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
@@ -119,7 +117,7 @@ func (s *awsKinesisStreamScaler) GetMetrics(ctx context.Context, metricName stri
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(shardCount), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(shardCount*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -132,29 +130,14 @@ func (s *awsKinesisStreamScaler) GetAwsKinesisOpenShardCount() (int64, error) {
 		StreamName: &s.metadata.streamName,
 	}
 
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(s.metadata.awsRegion),
-	}))
-
-	var kinesisClinent *kinesis.Kinesis
-	if s.metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(s.metadata.awsAuthorization.awsAccessKeyID, s.metadata.awsAuthorization.awsSecretAccessKey, "")
-
-		if s.metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, s.metadata.awsAuthorization.awsRoleArn)
-		}
-
-		kinesisClinent = kinesis.New(sess, &aws.Config{
-			Region:      aws.String(s.metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		kinesisClinent = kinesis.New(sess, &aws.Config{
-			Region: aws.String(s.metadata.awsRegion),
-		})
+	sess, config, err := getAwsSession(s.metadata.awsRegion, s.metadata.awsEndpoint, s.metadata.awsAuthorization)
+	if err != nil {
+		return -1, err
 	}
 
-	output, err := kinesisClinent.DescribeStreamSummary(input)
+	kinesisClient := kinesis.New(sess, config)
+
+	output, err := kinesisClient.DescribeStreamSummary(input)
 	if err != nil {
 		return -1, err
 	}
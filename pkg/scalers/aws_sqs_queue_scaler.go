@@ -7,11 +7,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	v2beta1 "k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -31,18 +27,19 @@ type awsSqsQueueScaler struct {
 }
 
 type awsSqsQueueMetadata struct {
-	targetQueueLength int
+	targetQueueLength float64
 	queueURL          string
 	queueName         string
 	awsRegion         string
+	awsEndpoint       string
 	awsAuthorization  awsAuthorizationMetadata
 }
 
 var sqsQueueLog = logf.Log.WithName("aws_sqs_queue_scaler")
 
 // NewAwsSqsQueueScaler creates a new awsSqsQueueScaler
-func NewAwsSqsQueueScaler(resolvedEnv, metadata map[string]string, authParams map[string]string) (Scaler, error) {
-	meta, err := parseAwsSqsQueueMetadata(metadata, resolvedEnv, authParams)
+func NewAwsSqsQueueScaler(resolvedEnv, metadata, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (Scaler, error) {
+	meta, err := parseAwsSqsQueueMetadata(metadata, resolvedEnv, authParams, awsWebIdentity)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing SQS queue metadata: %s", err)
 	}
@@ -52,12 +49,12 @@ func NewAwsSqsQueueScaler(resolvedEnv, metadata map[string]string, authParams ma
 	}, nil
 }
 
-func parseAwsSqsQueueMetadata(metadata, resolvedEnv, authParams map[string]string) (*awsSqsQueueMetadata, error) {
+func parseAwsSqsQueueMetadata(metadata, resolvedEnv, authParams map[string]string, awsWebIdentity *AwsWebIdentity) (*awsSqsQueueMetadata, error) {
 	meta := awsSqsQueueMetadata{}
 	meta.targetQueueLength = defaultTargetQueueLength
 
 	if val, ok := metadata["queueLength"]; ok && val != "" {
-		queueLength, err := strconv.Atoi(val)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			meta.targetQueueLength = targetQueueLengthDefault
 			sqsQueueLog.Error(err, "Error parsing SQS queue metadata queueLength, using default %n", targetQueueLengthDefault)
@@ -91,7 +88,9 @@ func parseAwsSqsQueueMetadata(metadata, resolvedEnv, authParams map[string]strin
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
-	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv)
+	meta.awsEndpoint = metadata["awsEndpoint"]
+
+	auth, err := getAwsAuthorization(authParams, metadata, resolvedEnv, awsWebIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +116,7 @@ func (s *awsSqsQueueScaler) Close() error {
 }
 
 func (s *awsSqsQueueScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetQueueLengthQty := resource.NewQuantity(int64(s.metadata.targetQueueLength), resource.DecimalSI)
+	targetQueueLengthQty := resource.NewMilliQuantity(int64(s.metadata.targetQueueLength*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: fmt.Sprintf("%s-%s-%s", "AWS-SQS-Queue", awsSqsQueueMetricName, s.metadata.queueName),
 		TargetAverageValue: targetQueueLengthQty}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
@@ -135,7 +134,7 @@ func (s *awsSqsQueueScaler) GetMetrics(ctx context.Context, metricName string, m
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(queuelen), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(queuelen)*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -149,28 +148,13 @@ func (s *awsSqsQueueScaler) GetAwsSqsQueueLength() (int32, error) {
 		QueueUrl:       aws.String(s.metadata.queueURL),
 	}
 
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(s.metadata.awsRegion),
-	}))
-
-	var sqsClient *sqs.SQS
-	if s.metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(s.metadata.awsAuthorization.awsAccessKeyID, s.metadata.awsAuthorization.awsSecretAccessKey, "")
-
-		if s.metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, s.metadata.awsAuthorization.awsRoleArn)
-		}
-
-		sqsClient = sqs.New(sess, &aws.Config{
-			Region:      aws.String(s.metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		sqsClient = sqs.New(sess, &aws.Config{
-			Region: aws.String(s.metadata.awsRegion),
-		})
+	sess, config, err := getAwsSession(s.metadata.awsRegion, s.metadata.awsEndpoint, s.metadata.awsAuthorization)
+	if err != nil {
+		return -1, err
 	}
 
+	sqsClient := sqs.New(sess, config)
+
 	output, err := sqsClient.GetQueueAttributes(input)
 	if err != nil {
 		return -1, err
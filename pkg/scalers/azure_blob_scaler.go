@@ -27,7 +27,7 @@ type azureBlobScaler struct {
 }
 
 type azureBlobMetadata struct {
-	targetBlobCount   int
+	targetBlobCount   float64
 	blobContainerName string
 	blobDelimiter     string
 	blobPrefix 		  string
@@ -58,7 +58,7 @@ func parseAzureBlobMetadata(metadata, resolvedEnv, authParams map[string]string,
 	meta.blobPrefix = defaultBlobPrefix
 
 	if val, ok := metadata[blobCountMetricName]; ok {
-		blobCount, err := strconv.Atoi(val)
+		blobCount, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			azureBlobLog.Error(err, "Error parsing azure blob metadata", "blobCountMetricName", blobCountMetricName)
 			return nil, "", fmt.Errorf("Error parsing azure blob metadata %s: %s", blobCountMetricName, err.Error())
@@ -151,7 +151,7 @@ func (s *azureBlobScaler) Close() error {
 }
 
 func (s *azureBlobScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetBlobCount := resource.NewQuantity(int64(s.metadata.targetBlobCount), resource.DecimalSI)
+	targetBlobCount := resource.NewMilliQuantity(int64(s.metadata.targetBlobCount*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: blobCountMetricName, TargetAverageValue: targetBlobCount}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta1.MetricSpec{metricSpec}
@@ -176,7 +176,7 @@ func (s *azureBlobScaler) GetMetrics(ctx context.Context, metricName string, met
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(bloblen), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(bloblen)*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -37,7 +37,7 @@ type AzureEventHubScaler struct {
 type EventHubMetadata struct {
 	eventHubConnection    string
 	eventHubConsumerGroup string
-	threshold             int64
+	threshold             float64
 	storageConnection     string
 	blobContainer         string
 }
@@ -72,7 +72,7 @@ func parseAzureEventHubMetadata(metadata, resolvedEnv map[string]string) (*Event
 	meta.threshold = defaultEventHubMessageThreshold
 
 	if val, ok := metadata[thresholdMetricName]; ok {
-		threshold, err := strconv.ParseInt(val, 10, 64)
+		threshold, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("Error parsing azure eventhub metadata %s: %s", thresholdMetricName, err)
 		}
@@ -178,7 +178,7 @@ func (scaler *AzureEventHubScaler) GetMetricSpecForScaling() []v2beta1.MetricSpe
 		{
 			External: &v2beta1.ExternalMetricSource{
 				MetricName:         thresholdMetricName,
-				TargetAverageValue: resource.NewQuantity(scaler.metadata.threshold, resource.DecimalSI),
+				TargetAverageValue: resource.NewMilliQuantity(int64(scaler.metadata.threshold*1000), resource.DecimalSI),
 			},
 			Type: eventHubMetricType,
 		},
@@ -224,7 +224,7 @@ func (scaler *AzureEventHubScaler) GetMetrics(ctx context.Context, metricName st
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(totalUnprocessedEventCount, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(totalUnprocessedEventCount*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
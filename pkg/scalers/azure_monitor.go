@@ -3,7 +3,6 @@ package scalers
 import (
 	"context"
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -28,8 +27,8 @@ type azureExternalMetricRequest struct {
 	ResourceGroup             string
 }
 
-// GetAzureMetricValue returns the value of an Azure Monitor metric, rounded to the nearest int
-func GetAzureMetricValue(ctx context.Context, metricMetadata *azureMonitorMetadata) (int32, error) {
+// GetAzureMetricValue returns the value of an Azure Monitor metric
+func GetAzureMetricValue(ctx context.Context, metricMetadata *azureMonitorMetadata) (float64, error) {
 	client := createMetricsClient(metricMetadata)
 
 	requestPtr, err := createMetricsRequest(metricMetadata)
@@ -75,17 +74,14 @@ func createMetricsRequest(metadata *azureMonitorMetadata) (*azureExternalMetricR
 	return &metricRequest, nil
 }
 
-func executeRequest(client insights.MetricsClient, request *azureExternalMetricRequest) (int32, error) {
+func executeRequest(client insights.MetricsClient, request *azureExternalMetricRequest) (float64, error) {
 	metricResponse, err := getAzureMetric(client, *request)
 	if err != nil {
 		azureMonitorLog.Error(err, "error getting azure monitor metric")
 		return -1, fmt.Errorf("Error getting azure monitor metric %s: %s", request.MetricName, err.Error())
 	}
 
-	// casting drops everything after decimal, so round first
-	metricValue := int32(math.Round(metricResponse))
-
-	return metricValue, nil
+	return metricResponse, nil
 }
 
 func getAzureMetric(client insights.MetricsClient, azMetricRequest azureExternalMetricRequest) (float64, error) {
@@ -36,7 +36,7 @@ type azureMonitorMetadata struct {
 	aggregationType     string
 	clientID            string
 	clientPassword      string
-	targetValue         int
+	targetValue         float64
 }
 
 var azureMonitorLog = logf.Log.WithName("azure_monitor_scaler")
@@ -57,7 +57,7 @@ func parseAzureMonitorMetadata(metadata, resolvedEnv, authParams map[string]stri
 	meta := azureMonitorMetadata{}
 
 	if val, ok := metadata[targetValueName]; ok && val != "" {
-		targetValue, err := strconv.Atoi(val)
+		targetValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			azureMonitorLog.Error(err, "Error parsing azure monitor metadata", "targetValue", targetValueName)
 			return nil, fmt.Errorf("Error parsing azure monitor metadata %s: %s", targetValueName, err.Error())
@@ -170,7 +170,7 @@ func (s *azureMonitorScaler) Close() error {
 }
 
 func (s *azureMonitorScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetMetricVal := resource.NewQuantity(int64(s.metadata.targetValue), resource.DecimalSI)
+	targetMetricVal := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: azureMonitorMetricName, TargetAverageValue: targetMetricVal}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta1.MetricSpec{metricSpec}
@@ -186,7 +186,7 @@ func (s *azureMonitorScaler) GetMetrics(ctx context.Context, metricName string,
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(val), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(val*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
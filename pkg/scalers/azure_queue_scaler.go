@@ -26,7 +26,7 @@ type azureQueueScaler struct {
 }
 
 type azureQueueMetadata struct {
-	targetQueueLength int
+	targetQueueLength float64
 	queueName         string
 	connection        string
 	useAAdPodIdentity bool
@@ -52,7 +52,7 @@ func parseAzureQueueMetadata(metadata, resolvedEnv, authParams map[string]string
 	meta.targetQueueLength = defaultTargetQueueLength
 
 	if val, ok := metadata[queueLengthMetricName]; ok {
-		queueLength, err := strconv.Atoi(val)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			azureQueueLog.Error(err, "Error parsing azure queue metadata", "queueLengthMetricName", queueLengthMetricName)
 			return nil, "", fmt.Errorf("Error parsing azure queue metadata %s: %s", queueLengthMetricName, err.Error())
@@ -132,7 +132,7 @@ func (s *azureQueueScaler) Close() error {
 }
 
 func (s *azureQueueScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetQueueLengthQty := resource.NewQuantity(int64(s.metadata.targetQueueLength), resource.DecimalSI)
+	targetQueueLengthQty := resource.NewMilliQuantity(int64(s.metadata.targetQueueLength*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: queueLengthMetricName, TargetAverageValue: targetQueueLengthQty}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta1.MetricSpec{metricSpec}
@@ -155,7 +155,7 @@ func (s *azureQueueScaler) GetMetrics(ctx context.Context, metricName string, me
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(queuelen), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(queuelen)*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
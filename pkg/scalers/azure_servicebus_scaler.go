@@ -32,7 +32,7 @@ type azureServiceBusScaler struct {
 }
 
 type azureServiceBusMetadata struct {
-	targetLength     int
+	targetLength     float64
 	queueName        string
 	topicName        string
 	subscriptionName string
@@ -62,7 +62,7 @@ func parseAzureServiceBusMetadata(resolvedEnv, metadata, authParams map[string]s
 
 	// get target metric value
 	if val, ok := metadata[queueLengthMetricName]; ok {
-		queueLength, err := strconv.Atoi(val)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			azureServiceBusLog.Error(err, "Error parsing azure queue metadata", "queueLengthMetricName", queueLengthMetricName)
 		} else {
@@ -144,7 +144,7 @@ func (s *azureServiceBusScaler) Close() error {
 
 // Returns the metric spec to be used by the HPA
 func (s *azureServiceBusScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetLengthQty := resource.NewQuantity(int64(s.metadata.targetLength), resource.DecimalSI)
+	targetLengthQty := resource.NewMilliQuantity(int64(s.metadata.targetLength*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: queueLengthMetricName, TargetAverageValue: targetLengthQty}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta1.MetricSpec{metricSpec}
@@ -161,7 +161,7 @@ func (s *azureServiceBusScaler) GetMetrics(ctx context.Context, metricName strin
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(queuelen), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(queuelen)*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
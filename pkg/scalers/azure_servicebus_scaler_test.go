@@ -86,6 +86,19 @@ func TestParseServiceBusMetadata(t *testing.T) {
 	}
 }
 
+func TestServiceBusFractionalQueueLength(t *testing.T) {
+	meta, err := parseAzureServiceBusMetadata(sampleResolvedEnv, map[string]string{"queueName": queueName, "connection": connectionSetting, "queueLength": "2.5"}, map[string]string{}, "")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	scaler := azureServiceBusScaler{metadata: meta}
+	target := scaler.GetMetricSpecForScaling()[0].External.TargetAverageValue
+	if target.MilliValue() != 2500 {
+		t.Errorf("Expected a target of 2500m but got %s", target.String())
+	}
+}
+
 func TestGetServiceBusLength(t *testing.T) {
 
 	t.Log("This test will use the environment variable SERVICEBUS_CONNECTION_STRING if it is set")
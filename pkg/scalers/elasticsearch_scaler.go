@@ -0,0 +1,278 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	elasticsearchDefaultTimeout = 3000
+)
+
+type elasticsearchScaler struct {
+	metadata   *elasticsearchMetadata
+	httpClient *http.Client
+}
+
+type elasticsearchMetadata struct {
+	addresses          []string
+	indexes            []string
+	searchTemplateName string
+	parameters         map[string]string
+	valueLocation      string
+	targetValue        float64
+	timeout            time.Duration
+
+	// authentication
+	username string
+	password string
+	apiKey   string
+	ca       string
+}
+
+var elasticsearchLog = logf.Log.WithName("elasticsearch_scaler")
+
+// NewElasticsearchScaler creates a new elasticsearch scaler
+func NewElasticsearchScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseElasticsearchMetadata(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing elasticsearch metadata: %s", err)
+	}
+
+	httpClient := &http.Client{Timeout: meta.timeout}
+	if meta.ca != "" {
+		tlsConfig, err := newTLSConfig("", "", meta.ca)
+		if err != nil {
+			return nil, fmt.Errorf("error creating elasticsearch tls config: %s", err)
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+
+	return &elasticsearchScaler{
+		metadata:   meta,
+		httpClient: httpClient,
+	}, nil
+}
+
+func parseElasticsearchMetadata(metadata, resolvedEnv, authParams map[string]string) (*elasticsearchMetadata, error) {
+	meta := elasticsearchMetadata{}
+
+	addresses := ""
+	if val, ok := authParams["addresses"]; ok && val != "" {
+		addresses = val
+	} else if val, ok := metadata["addresses"]; ok && val != "" {
+		addresses = val
+	}
+	meta.addresses = parseElasticsearchList(addresses)
+	if len(meta.addresses) == 0 {
+		return nil, fmt.Errorf("no addresses given")
+	}
+
+	if val, ok := metadata["index"]; ok && val != "" {
+		meta.indexes = parseElasticsearchList(val)
+	}
+	if len(meta.indexes) == 0 {
+		return nil, fmt.Errorf("no index given")
+	}
+
+	if val, ok := metadata["searchTemplateName"]; ok && val != "" {
+		meta.searchTemplateName = val
+	} else {
+		return nil, fmt.Errorf("no searchTemplateName given")
+	}
+
+	meta.parameters = map[string]string{}
+	if val, ok := metadata["parameters"]; ok && val != "" {
+		for _, param := range strings.Split(val, ",") {
+			param = strings.TrimSpace(param)
+			if param == "" {
+				continue
+			}
+
+			parts := strings.SplitN(param, "=", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				return nil, fmt.Errorf("error parsing parameters: %s should be in the format of key=value", param)
+			}
+			meta.parameters[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	if val, ok := metadata["valueLocation"]; ok && val != "" {
+		meta.valueLocation = val
+	} else {
+		return nil, fmt.Errorf("no valueLocation given")
+	}
+
+	if val, ok := metadata["targetValue"]; ok && val != "" {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("targetValue parsing error %s", err.Error())
+		}
+		meta.targetValue = targetValue
+	} else {
+		return nil, fmt.Errorf("no targetValue given")
+	}
+
+	meta.timeout = elasticsearchDefaultTimeout * time.Millisecond
+	if val, ok := metadata["timeout"]; ok && val != "" {
+		t, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("timeout parsing error %s", err.Error())
+		}
+		if t <= 0 {
+			return nil, fmt.Errorf("timeout must be greater than 0")
+		}
+		meta.timeout = time.Duration(t) * time.Millisecond
+	}
+
+	if val, ok := authParams["username"]; ok && val != "" {
+		meta.username = val
+	} else if val, ok := metadata["username"]; ok && val != "" {
+		meta.username = val
+	}
+
+	if val, ok := authParams["password"]; ok && val != "" {
+		meta.password = val
+	} else if val, ok := metadata["passwordFromEnv"]; ok && val != "" {
+		meta.password = resolvedEnv[val]
+	}
+
+	meta.apiKey = authParams["apiKey"]
+	if meta.apiKey != "" && meta.username != "" {
+		return nil, fmt.Errorf("both apiKey and username can't be used at the same time")
+	}
+
+	meta.ca = authParams["ca"]
+
+	return &meta, nil
+}
+
+// parseElasticsearchList splits a comma-separated list and drops the empty entries
+func parseElasticsearchList(val string) []string {
+	items := []string{}
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getValueFromSearch runs the search template and returns the value at valueLocation,
+// the addresses are tried in order until one of them answers
+func (s *elasticsearchScaler) getValueFromSearch(ctx context.Context) (float64, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"id":     s.metadata.searchTemplateName,
+		"params": s.metadata.parameters,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var lastErr error
+	for _, address := range s.metadata.addresses {
+		response, err := s.searchTemplate(ctx, address, body)
+		if err != nil {
+			elasticsearchLog.Error(err, "error querying elasticsearch", "address", address)
+			lastErr = err
+			continue
+		}
+
+		return getJSONValue(response, s.metadata.valueLocation)
+	}
+
+	return 0, lastErr
+}
+
+func (s *elasticsearchScaler) searchTemplate(ctx context.Context, address string, body []byte) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s/_search/template", strings.TrimRight(address, "/"), strings.Join(s.metadata.indexes, ","))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.metadata.apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("ApiKey %s", s.metadata.apiKey))
+	} else if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	r, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("elasticsearch search template api returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	return b, nil
+}
+
+// Close does nothing for the elasticsearch scaler
+func (s *elasticsearchScaler) Close() error {
+	return nil
+}
+
+// IsActive returns true if the value at valueLocation is greater than zero
+func (s *elasticsearchScaler) IsActive(ctx context.Context) (bool, error) {
+	val, err := s.getValueFromSearch(ctx)
+	if err != nil {
+		elasticsearchLog.Error(err, "error inspecting elasticsearch")
+		return false, err
+	}
+	return val > 0, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *elasticsearchScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	targetValue := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
+	externalMetric := &v2beta1.ExternalMetricSource{
+		MetricName:         fmt.Sprintf("%s-%s", "elasticsearch", sanitizeMetricName(s.metadata.searchTemplateName)),
+		TargetAverageValue: targetValue,
+	}
+	metricSpec := v2beta1.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2beta1.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *elasticsearchScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	val, err := s.getValueFromSearch(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting elasticsearch: %s", err)
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(int64(val*1000), resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
@@ -0,0 +1,158 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testElasticsearchResolvedEnv = map[string]string{
+	"ES_PASSWORD": "pass",
+}
+
+type parseElasticsearchMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testElasticsearchMetadata = []parseElasticsearchMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"addresses": "http://es-0:9200,http://es-1:9200", "index": "logs", "searchTemplateName": "pending", "parameters": "queue=ingest,since=now-5m", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, false},
+	// addresses from authParams, several indexes
+	{map[string]string{"index": "logs-1, logs-2", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "0.5"}, map[string]string{"addresses": "https://es:9200"}, false},
+	// basic auth, password from env
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10", "username": "elastic", "passwordFromEnv": "ES_PASSWORD"}, map[string]string{}, false},
+	// api key
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{"apiKey": "key"}, false},
+	// api key and basic auth
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{"apiKey": "key", "username": "elastic"}, true},
+	// missing addresses
+	{map[string]string{"addresses": " , ", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true},
+	// missing index
+	{map[string]string{"addresses": "http://es:9200", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true},
+	// missing searchTemplateName
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true},
+	// malformed parameters
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "parameters": "queue", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true},
+	// missing valueLocation
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "targetValue": "10"}, map[string]string{}, true},
+	// missing targetValue
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value"}, map[string]string{}, true},
+	// malformed targetValue
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "ten"}, map[string]string{}, true},
+	// malformed timeout
+	{map[string]string{"addresses": "http://es:9200", "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10", "timeout": "0"}, map[string]string{}, true},
+}
+
+func TestParseElasticsearchMetadata(t *testing.T) {
+	for _, testData := range testElasticsearchMetadata {
+		_, err := parseElasticsearchMetadata(testData.metadata, testElasticsearchResolvedEnv, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+const testElasticsearchResponse = `{"hits": {"total": {"value": 42, "relation": "eq"}}, "aggregations": {"queues": {"buckets": [{"key": "ingest", "doc_count": 7}]}, "latency": {"value": "0.25"}}}`
+
+func TestElasticsearchGetMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/logs-1,logs-2/_search/template" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "ApiKey key" {
+			t.Errorf("Expected api key but got %s", r.Header.Get("Authorization"))
+		}
+
+		var body struct {
+			ID     string            `json:"id"`
+			Params map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error("Expected a JSON body but got error", err)
+		}
+		if body.ID != "pending" || body.Params["queue"] != "ingest" {
+			t.Errorf("Unexpected search template request %+v", body)
+		}
+		fmt.Fprint(w, testElasticsearchResponse)
+	}))
+	defer server.Close()
+
+	// the first address is not reachable, the scaler falls back to the next one
+	metadata := map[string]string{"addresses": "http://127.0.0.1:1," + server.URL, "index": "logs-1,logs-2", "searchTemplateName": "pending", "parameters": "queue=ingest", "valueLocation": "aggregations.latency.value", "targetValue": "0.5"}
+	scaler, err := NewElasticsearchScaler(map[string]string{}, metadata, map[string]string{"apiKey": "key"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	target := scaler.GetMetricSpecForScaling()[0].External
+	if target.MetricName != "elasticsearch-pending" || target.TargetAverageValue.String() != "500m" {
+		t.Errorf("Expected metric elasticsearch-pending with target 500m but got %s with %s", target.MetricName, target.TargetAverageValue.String())
+	}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "elasticsearch-pending", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if metrics[0].Value.String() != "250m" {
+		t.Errorf("Expected value 250m but got %s", metrics[0].Value.String())
+	}
+
+	isActive, err := scaler.IsActive(context.TODO())
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !isActive {
+		t.Error("Expected active but got inactive")
+	}
+}
+
+func TestElasticsearchBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "elastic" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"hits": {"total": {"value": 0}}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"addresses": server.URL, "index": "logs", "searchTemplateName": "pending", "valueLocation": "hits.total.value", "targetValue": "10", "username": "elastic", "passwordFromEnv": "ES_PASSWORD"}
+	scaler, err := NewElasticsearchScaler(testElasticsearchResolvedEnv, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if isActive, err := scaler.IsActive(context.TODO()); err != nil || isActive {
+		t.Errorf("Expected inactive without error but got %t, %v", isActive, err)
+	}
+
+	scaler, err = NewElasticsearchScaler(testElasticsearchResolvedEnv, metadata, map[string]string{"password": "wrong"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error for wrong credentials but got success")
+	}
+}
+
+func TestElasticsearchMetricName(t *testing.T) {
+	metadata := map[string]string{"addresses": "http://elasticsearch:9200", "index": "logs", "searchTemplateName": "pending_jobs.v2", "valueLocation": "hits.total.value", "targetValue": "10"}
+	meta, err := parseElasticsearchMetadata(metadata, map[string]string{}, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	scaler := elasticsearchScaler{metadata: meta}
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "elasticsearch-pending-jobs-v2" {
+		t.Errorf("Expected metric elasticsearch-pending-jobs-v2 but got %s", name)
+	}
+}
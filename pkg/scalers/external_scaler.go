@@ -143,8 +143,11 @@ func (s *externalScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 	var result []v2beta1.MetricSpec
 
 	for _, spec := range response.MetricSpecs {
-		// Construct the target subscription size as a quantity
+		// Construct the target subscription size as a quantity, targetSizeFloat takes precedence when set
 		qty := resource.NewQuantity(int64(spec.TargetSize), resource.DecimalSI)
+		if spec.TargetSizeFloat != 0 {
+			qty = resource.NewMilliQuantity(int64(spec.TargetSizeFloat*1000), resource.DecimalSI)
+		}
 
 		externalMetric := &v2beta1.ExternalMetricSource{
 			MetricName:         spec.MetricName,
@@ -181,9 +184,15 @@ func (s *externalScaler) GetMetrics(ctx context.Context, metricName string, metr
 	}
 
 	for _, metricResult := range response.MetricValues {
+		// metricValueFloat takes precedence when set
+		value := resource.NewQuantity(metricResult.MetricValue, resource.DecimalSI)
+		if metricResult.MetricValueFloat != 0 {
+			value = resource.NewMilliQuantity(int64(metricResult.MetricValueFloat*1000), resource.DecimalSI)
+		}
+
 		metric := external_metrics.ExternalMetricValue{
 			MetricName: metricResult.MetricName,
-			Value:      *resource.NewQuantity(metricResult.MetricValue, resource.DecimalSI),
+			Value:      *value,
 			Timestamp:  metav1.Now(),
 		}
 
@@ -200,6 +200,7 @@ func (m *GetMetricSpecResponse) GetMetricSpecs() []*MetricSpec {
 type MetricSpec struct {
 	MetricName           string   `protobuf:"bytes,1,opt,name=metricName,proto3" json:"metricName,omitempty"`
 	TargetSize           int64    `protobuf:"varint,2,opt,name=targetSize,proto3" json:"targetSize,omitempty"`
+	TargetSizeFloat      float64  `protobuf:"fixed64,3,opt,name=targetSizeFloat,proto3" json:"targetSizeFloat,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -244,6 +245,13 @@ func (m *MetricSpec) GetTargetSize() int64 {
 	return 0
 }
 
+func (m *MetricSpec) GetTargetSizeFloat() float64 {
+	if m != nil {
+		return m.TargetSizeFloat
+	}
+	return 0
+}
+
 type GetMetricsRequest struct {
 	ScaledObjectRef      *ScaledObjectRef `protobuf:"bytes,1,opt,name=scaledObjectRef,proto3" json:"scaledObjectRef,omitempty"`
 	MetricName           string           `protobuf:"bytes,2,opt,name=metricName,proto3" json:"metricName,omitempty"`
@@ -333,6 +341,7 @@ func (m *GetMetricsResponse) GetMetricValues() []*MetricValue {
 type MetricValue struct {
 	MetricName           string   `protobuf:"bytes,1,opt,name=metricName,proto3" json:"metricName,omitempty"`
 	MetricValue          int64    `protobuf:"varint,2,opt,name=metricValue,proto3" json:"metricValue,omitempty"`
+	MetricValueFloat     float64  `protobuf:"fixed64,3,opt,name=metricValueFloat,proto3" json:"metricValueFloat,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -377,6 +386,13 @@ func (m *MetricValue) GetMetricValue() int64 {
 	return 0
 }
 
+func (m *MetricValue) GetMetricValueFloat() float64 {
+	if m != nil {
+		return m.MetricValueFloat
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ScaledObjectRef)(nil), "externalscaler.ScaledObjectRef")
 	proto.RegisterType((*NewRequest)(nil), "externalscaler.NewRequest")
@@ -392,38 +408,39 @@ func init() {
 func init() { proto.RegisterFile("externalscaler.proto", fileDescriptor_3d382708546499d1) }
 
 var fileDescriptor_3d382708546499d1 = []byte{
-	// 484 bytes of a gzipped FileDescriptorProto
+	// 504 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0x63, 0x5a, 0xa5, 0x13, 0xfa, 0xc1, 0xa8, 0x54, 0x91, 0x8b, 0x20, 0xac, 0x84, 0x14,
-	0x71, 0x70, 0xa5, 0x70, 0x41, 0x14, 0x09, 0x41, 0x89, 0x50, 0x25, 0x9a, 0x48, 0x1b, 0xa5, 0x12,
-	0xc7, 0x8d, 0x3b, 0x8d, 0x02, 0x4e, 0x6c, 0xbc, 0xeb, 0x96, 0x70, 0xe0, 0x57, 0xf0, 0xeb, 0xf8,
-	0x35, 0xc8, 0xeb, 0xef, 0x55, 0x43, 0xb8, 0xf4, 0x64, 0xef, 0xbc, 0x37, 0xb3, 0xf3, 0xde, 0xcc,
-	0xc2, 0x21, 0xfd, 0x50, 0x14, 0x2d, 0x85, 0x2f, 0x3d, 0xe1, 0x53, 0xe4, 0x86, 0x51, 0xa0, 0x02,
-	0xdc, 0xab, 0x47, 0x9d, 0xe3, 0x59, 0x10, 0xcc, 0x7c, 0x3a, 0xd1, 0xe8, 0x34, 0xbe, 0x3e, 0xa1,
-	0x45, 0xa8, 0x56, 0x29, 0x99, 0x9d, 0xc1, 0xfe, 0x38, 0xa1, 0x5d, 0x8d, 0xa6, 0x5f, 0xc9, 0x53,
-	0x9c, 0xae, 0x11, 0xe1, 0xc1, 0x52, 0x2c, 0xa8, 0x63, 0x75, 0xad, 0xde, 0x0e, 0xd7, 0xff, 0xf8,
-	0x04, 0x76, 0x92, 0xaf, 0x0c, 0x85, 0x47, 0x9d, 0xa6, 0x06, 0xca, 0x00, 0xfb, 0x63, 0x01, 0x0c,
-	0xe9, 0x96, 0xd3, 0xf7, 0x98, 0xa4, 0xc2, 0x73, 0xd8, 0x97, 0xf5, 0x9a, 0xba, 0x56, 0xbb, 0xff,
-	0xcc, 0x35, 0x1a, 0x36, 0xae, 0xe6, 0x66, 0x1e, 0x7e, 0x84, 0xd6, 0x82, 0x94, 0xb8, 0x12, 0x4a,
-	0x74, 0x9a, 0x5d, 0xbb, 0xd7, 0xee, 0xf7, 0xcc, 0x1a, 0xe5, 0xc5, 0xee, 0x45, 0x46, 0x1d, 0x2c,
-	0x55, 0xb4, 0xe2, 0x45, 0xa6, 0x73, 0x0a, 0xbb, 0x35, 0x08, 0x0f, 0xc0, 0xfe, 0x46, 0xab, 0x4c,
-	0x61, 0xf2, 0x8b, 0x87, 0xb0, 0x75, 0x23, 0xfc, 0x38, 0x17, 0x97, 0x1e, 0xde, 0x34, 0x5f, 0x5b,
-	0xec, 0x25, 0x1c, 0x9c, 0xcb, 0xf7, 0x9e, 0x9a, 0xdf, 0x10, 0x27, 0x19, 0x06, 0x4b, 0x49, 0x78,
-	0x04, 0xdb, 0x11, 0xc9, 0xd8, 0x57, 0xba, 0x44, 0x8b, 0x67, 0x27, 0x36, 0x81, 0xc7, 0x9f, 0x48,
-	0x5d, 0x90, 0x8a, 0xe6, 0xde, 0x38, 0x24, 0xaf, 0x48, 0x78, 0x0b, 0xed, 0x45, 0x11, 0x95, 0x1d,
-	0x4b, 0x4b, 0x71, 0x4c, 0x29, 0x95, 0xc4, 0x2a, 0x9d, 0x7d, 0x06, 0x28, 0x21, 0x7c, 0x0a, 0x90,
-	0x82, 0xc3, 0x72, 0x4a, 0x95, 0x48, 0x82, 0x2b, 0x11, 0xcd, 0x48, 0x8d, 0xe7, 0x3f, 0x53, 0x3d,
-	0x36, 0xaf, 0x44, 0xd8, 0x2f, 0x78, 0x54, 0x34, 0x29, 0xef, 0x61, 0x66, 0xf5, 0xfe, 0x9a, 0x66,
-	0x7f, 0x6c, 0x02, 0x58, 0xbd, 0x3f, 0x73, 0xe8, 0x1d, 0x3c, 0x4c, 0x39, 0x97, 0x89, 0xf3, 0xb9,
-	0x45, 0xc7, 0x77, 0x5b, 0xa4, 0x39, 0xbc, 0x96, 0xc0, 0x46, 0xd0, 0xae, 0x80, 0x1b, 0x5d, 0xea,
-	0xe6, 0x13, 0xb9, 0x2c, 0xc6, 0x6e, 0xf3, 0x6a, 0xa8, 0xff, 0xdb, 0x86, 0xbd, 0x41, 0x76, 0xbb,
-	0x16, 0x1d, 0xe1, 0x29, 0xd8, 0x43, 0xba, 0x45, 0x67, 0xfd, 0x0e, 0x3a, 0x47, 0x6e, 0xfa, 0xdc,
-	0xdc, 0xfc, 0xb9, 0xb9, 0x83, 0xe4, 0xb9, 0xb1, 0x06, 0x8e, 0xa0, 0x95, 0x2f, 0x12, 0x6e, 0x72,
-	0xd5, 0xe9, 0x9a, 0x04, 0x73, 0x07, 0x59, 0x03, 0xbf, 0xc0, 0x6e, 0x6d, 0xdb, 0x36, 0x57, 0x7d,
-	0x61, 0x12, 0xee, 0xdc, 0x56, 0xd6, 0xc0, 0x09, 0x40, 0x39, 0x23, 0x7c, 0xbe, 0x36, 0x2d, 0xdf,
-	0x1f, 0x87, 0xfd, 0x8b, 0x52, 0x94, 0xfd, 0x00, 0x5b, 0x67, 0x7e, 0x20, 0xff, 0x43, 0xff, 0x5a,
-	0x1b, 0xa7, 0xdb, 0x3a, 0xf2, 0xea, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x69, 0x62, 0xa3, 0x07,
-	0xfd, 0x04, 0x00, 0x00,
+	0x10, 0xad, 0x63, 0x5a, 0xa5, 0x13, 0xda, 0x84, 0x51, 0xa9, 0x22, 0x17, 0x81, 0x59, 0x09, 0xc9,
+	0xea, 0xc1, 0x95, 0xc2, 0x05, 0x51, 0x24, 0x04, 0x25, 0xa0, 0x1e, 0x9a, 0x4a, 0x1b, 0xa5, 0x12,
+	0xc7, 0x8d, 0x3b, 0x8d, 0x02, 0x4e, 0x6c, 0xbc, 0x9b, 0x94, 0x80, 0xc4, 0xaf, 0xe0, 0xd7, 0xf1,
+	0x6b, 0x90, 0xbf, 0xed, 0xa5, 0x21, 0x5c, 0x38, 0xd9, 0xfb, 0xe6, 0xcd, 0xec, 0xbe, 0x37, 0x33,
+	0x70, 0x40, 0x5f, 0x15, 0x45, 0x73, 0xe1, 0x4b, 0x4f, 0xf8, 0x14, 0xb9, 0x61, 0x14, 0xa8, 0x00,
+	0xf7, 0xeb, 0xa8, 0x75, 0x34, 0x09, 0x82, 0x89, 0x4f, 0x27, 0x49, 0x74, 0xbc, 0xb8, 0x39, 0xa1,
+	0x59, 0xa8, 0x56, 0x29, 0x99, 0x9d, 0x41, 0x7b, 0x18, 0xd3, 0xae, 0x2f, 0xc7, 0x9f, 0xc8, 0x53,
+	0x9c, 0x6e, 0x10, 0xe1, 0xde, 0x5c, 0xcc, 0xa8, 0x6b, 0xd8, 0x86, 0xb3, 0xcb, 0x93, 0x7f, 0x7c,
+	0x04, 0xbb, 0xf1, 0x57, 0x86, 0xc2, 0xa3, 0x6e, 0x23, 0x09, 0x94, 0x00, 0xfb, 0x65, 0x00, 0x0c,
+	0xe8, 0x96, 0xd3, 0x97, 0x05, 0x49, 0x85, 0xe7, 0xd0, 0x96, 0xf5, 0x9a, 0x49, 0xad, 0x56, 0xef,
+	0x89, 0xab, 0x3d, 0x58, 0xbb, 0x9a, 0xeb, 0x79, 0xf8, 0x0e, 0x9a, 0x33, 0x52, 0xe2, 0x5a, 0x28,
+	0xd1, 0x6d, 0xd8, 0xa6, 0xd3, 0xea, 0x39, 0x7a, 0x8d, 0xf2, 0x62, 0xf7, 0x22, 0xa3, 0xf6, 0xe7,
+	0x2a, 0x5a, 0xf1, 0x22, 0xd3, 0x3a, 0x85, 0xbd, 0x5a, 0x08, 0x3b, 0x60, 0x7e, 0xa6, 0x55, 0xa6,
+	0x30, 0xfe, 0xc5, 0x03, 0xd8, 0x5e, 0x0a, 0x7f, 0x91, 0x8b, 0x4b, 0x0f, 0x2f, 0x1b, 0x2f, 0x0c,
+	0x76, 0x0c, 0x9d, 0x73, 0xf9, 0xc6, 0x53, 0xd3, 0x25, 0x71, 0x92, 0x61, 0x30, 0x97, 0x84, 0x87,
+	0xb0, 0x13, 0x91, 0x5c, 0xf8, 0x2a, 0x29, 0xd1, 0xe4, 0xd9, 0x89, 0x8d, 0xe0, 0xe1, 0x07, 0x52,
+	0x17, 0xa4, 0xa2, 0xa9, 0x37, 0x0c, 0xc9, 0x2b, 0x12, 0x5e, 0x41, 0x6b, 0x56, 0xa0, 0xb2, 0x6b,
+	0x24, 0x52, 0x2c, 0x5d, 0x4a, 0x25, 0xb1, 0x4a, 0x67, 0x4b, 0x80, 0x32, 0x84, 0x8f, 0x01, 0xd2,
+	0xe0, 0xa0, 0xec, 0x52, 0x05, 0x89, 0xe3, 0x4a, 0x44, 0x13, 0x52, 0xc3, 0xe9, 0xb7, 0x54, 0x8f,
+	0xc9, 0x2b, 0x08, 0x3a, 0xd0, 0x2e, 0x4f, 0xef, 0xfd, 0x40, 0xa8, 0xae, 0x69, 0x1b, 0x8e, 0xc1,
+	0x75, 0x98, 0xfd, 0x80, 0x07, 0x85, 0x1c, 0xf9, 0x1f, 0xba, 0x5b, 0x57, 0xd2, 0xd0, 0x95, 0xb0,
+	0x11, 0x60, 0xf5, 0xfe, 0xcc, 0xcb, 0xd7, 0x70, 0x3f, 0xe5, 0x5c, 0xc5, 0x3d, 0xca, 0xcd, 0x3c,
+	0xba, 0xdb, 0xcc, 0x84, 0xc3, 0x6b, 0x09, 0xec, 0x3b, 0xb4, 0x2a, 0xc1, 0x8d, 0x7e, 0xda, 0x79,
+	0xef, 0xae, 0x8a, 0x01, 0x31, 0x79, 0x15, 0xc2, 0x63, 0xe8, 0x54, 0x8e, 0x55, 0x4b, 0xff, 0xc0,
+	0x7b, 0x3f, 0x4d, 0xd8, 0xef, 0x67, 0x2f, 0x4d, 0x0c, 0x8a, 0xf0, 0x14, 0xcc, 0x01, 0xdd, 0xa2,
+	0xb5, 0x7e, 0xb2, 0xad, 0x43, 0x37, 0x5d, 0x62, 0x37, 0x5f, 0x62, 0xb7, 0x1f, 0x2f, 0x31, 0xdb,
+	0xc2, 0x4b, 0x68, 0xe6, 0xe3, 0x89, 0x9b, 0x3a, 0x60, 0xd9, 0x3a, 0x41, 0x9f, 0x6c, 0xb6, 0x85,
+	0x1f, 0x61, 0xaf, 0x36, 0xc3, 0x9b, 0xab, 0x3e, 0xd3, 0x09, 0x77, 0xee, 0x00, 0xdb, 0xc2, 0x11,
+	0x40, 0xd9, 0x4f, 0x7c, 0xba, 0x36, 0x2d, 0x9f, 0x35, 0x8b, 0xfd, 0x8d, 0x52, 0x94, 0x7d, 0x0b,
+	0xdb, 0x67, 0x7e, 0x20, 0xff, 0x41, 0xff, 0x5a, 0x1b, 0xc7, 0x3b, 0x09, 0xf2, 0xfc, 0xf7, 0x00,
+	0x90, 0xe4, 0xc5, 0xc6, 0x53, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -24,7 +24,7 @@ type pubsubScaler struct {
 }
 
 type pubsubMetadata struct {
-	targetSubscriptionSize int
+	targetSubscriptionSize float64
 	subscriptionName       string
 	credentials            string
 }
@@ -48,7 +48,7 @@ func parsePubSubMetadata(metadata, resolvedEnv map[string]string) (*pubsubMetada
 	meta.targetSubscriptionSize = defaultTargetSubscriptionSize
 
 	if val, ok := metadata["subscriptionSize"]; ok {
-		subscriptionSize, err := strconv.Atoi(val)
+		subscriptionSize, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("Subscription Size parsing error %s", err.Error())
 		}
@@ -100,7 +100,7 @@ func (s *pubsubScaler) Close() error {
 func (s *pubsubScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 
 	// Construct the target subscription size as a quantity
-	targetSubscriptionSizeQty := resource.NewQuantity(int64(s.metadata.targetSubscriptionSize), resource.DecimalSI)
+	targetSubscriptionSizeQty := resource.NewMilliQuantity(int64(s.metadata.targetSubscriptionSize*1000), resource.DecimalSI)
 
 	externalMetric := &v2beta1.ExternalMetricSource{
 		MetricName:         pubSubSubscriptionSizeMetricName,
@@ -127,7 +127,7 @@ func (s *pubsubScaler) GetMetrics(ctx context.Context, metricName string, metric
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(size, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(size*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -231,7 +231,7 @@ func (h *huaweiCloudeyeScaler) GetMetrics(ctx context.Context, metricName string
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(metricValue), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(metricValue*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -239,7 +239,7 @@ func (h *huaweiCloudeyeScaler) GetMetrics(ctx context.Context, metricName string
 }
 
 func (h *huaweiCloudeyeScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetMetricValue := resource.NewQuantity(int64(h.metadata.targetMetricValue), resource.DecimalSI)
+	targetMetricValue := resource.NewMilliQuantity(int64(h.metadata.targetMetricValue*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: fmt.Sprintf("%s-%s-%s-%s", strings.ReplaceAll(h.metadata.namespace, ".", "-"),
 		h.metadata.metricsName,
 		h.metadata.dimensionName, h.metadata.dimensionValue),
@@ -0,0 +1,72 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const jolokiaHTTPTimeout = 3 * time.Second
+
+// jolokiaReadResponse is the response of a Jolokia read request, the HTTP status is 200
+// for most errors, the actual status is in the body
+type jolokiaReadResponse struct {
+	Value     json.Number `json:"value"`
+	Status    int         `json:"status"`
+	Error     string      `json:"error"`
+	ErrorType string      `json:"error_type"`
+}
+
+// jolokiaPathEscaper escapes the / of an MBean name the way Jolokia expects in a read url
+var jolokiaPathEscaper = strings.NewReplacer("!", "!!", "/", "!/")
+
+// escapeJolokiaPath escapes an MBean name for a Jolokia read url. A / in a destination name is sent as !/
+// instead of %2F, which servlet containers like Jetty and Tomcat reject by default.
+func escapeJolokiaPath(mbean string) string {
+	segments := strings.Split(jolokiaPathEscaper.Replace(mbean), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// getJolokiaAttribute reads a numeric MBean attribute through a Jolokia read url
+func getJolokiaAttribute(ctx context.Context, client *http.Client, url, username, password string) (int64, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	r, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer r.Body.Close()
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("jolokia returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	var response jolokiaReadResponse
+	if err := json.Unmarshal(b, &response); err != nil {
+		return 0, err
+	}
+	if response.Status != http.StatusOK {
+		return 0, fmt.Errorf("jolokia returned error. status: %d error: %s", response.Status, response.Error)
+	}
+
+	return response.Value.Int64()
+}
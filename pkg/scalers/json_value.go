@@ -0,0 +1,52 @@
+package scalers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// getJSONValue returns the number found in a JSON document at a dot-separated, GJSON-style location,
+// eg. hits.total.value or aggregations.queues.buckets.0.doc_count, # returns the length of an array
+func getJSONValue(document []byte, valueLocation string) (float64, error) {
+	var current interface{}
+	if err := json.Unmarshal(document, &current); err != nil {
+		return 0, err
+	}
+
+	for _, key := range strings.Split(valueLocation, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			val, ok := node[key]
+			if !ok {
+				return 0, fmt.Errorf("valueLocation %s not found in the response, missing %s", valueLocation, key)
+			}
+			current = val
+		case []interface{}:
+			if key == "#" {
+				current = float64(len(node))
+				continue
+			}
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return 0, fmt.Errorf("valueLocation %s not found in the response, invalid index %s", valueLocation, key)
+			}
+			current = node[i]
+		default:
+			return 0, fmt.Errorf("valueLocation %s not found in the response, %s is not an object or array", valueLocation, key)
+		}
+	}
+
+	switch val := current.(type) {
+	case float64:
+		return val, nil
+	case string:
+		v, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value at %s is not a number: %s", valueLocation, val)
+		}
+		return v, nil
+	}
+	return 0, fmt.Errorf("value at %s is not a number", valueLocation)
+}
@@ -0,0 +1,44 @@
+package scalers
+
+import (
+	"testing"
+)
+
+type jsonValueTestData struct {
+	valueLocation string
+	isError       bool
+	expectedValue float64
+}
+
+const testJSONValueDocument = `{"hits": {"total": {"value": 42, "relation": "eq"}}, "aggregations": {"queues": {"buckets": [{"key": "ingest", "doc_count": 7}, {"key": "export", "doc_count": 3}]}, "latency": {"value": "0.25"}}}`
+
+var testJSONValues = []jsonValueTestData{
+	{"hits.total.value", false, 42},
+	{"aggregations.queues.buckets.0.doc_count", false, 7},
+	{"aggregations.queues.buckets.#", false, 2},
+	{"aggregations.latency.value", false, 0.25},
+	{"hits.total.relation", true, 0},
+	{"hits.total", true, 0},
+	{"hits.count", true, 0},
+	{"aggregations.queues.buckets.2.doc_count", true, 0},
+	{"hits.#", true, 0},
+	{"hits.total.value.count", true, 0},
+}
+
+func TestGetJSONValue(t *testing.T) {
+	for _, testData := range testJSONValues {
+		value, err := getJSONValue([]byte(testJSONValueDocument), testData.valueLocation)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.valueLocation, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.valueLocation)
+		}
+		if value != testData.expectedValue {
+			t.Errorf("%s: expected value %f but got %f", testData.valueLocation, testData.expectedValue, value)
+		}
+	}
+	if _, err := getJSONValue([]byte("not json"), "value"); err == nil {
+		t.Error("Expected error for invalid JSON but got success")
+	}
+}
@@ -0,0 +1,39 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// kafkaOAuthTokenProvider provides SASL/OAUTHBEARER tokens fetched from an OAuth2 client credentials endpoint.
+// The token is reused until it expires and then refreshed.
+type kafkaOAuthTokenProvider struct {
+	tokenSource oauth2.TokenSource
+}
+
+func newKafkaOAuthTokenProvider(clientID, clientSecret, tokenURL string, scopes []string) sarama.AccessTokenProvider {
+	config := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       scopes,
+	}
+
+	return &kafkaOAuthTokenProvider{
+		tokenSource: config.TokenSource(context.Background()),
+	}
+}
+
+// Token returns a valid access token, fetching a new one if needed
+func (p *kafkaOAuthTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth token: %s", err)
+	}
+
+	return &sarama.AccessToken{Token: token.AccessToken}, nil
+}
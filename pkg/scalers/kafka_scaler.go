@@ -3,7 +3,6 @@ package scalers
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"strconv"
@@ -28,8 +27,11 @@ type kafkaScaler struct {
 type kafkaMetadata struct {
 	bootstrapServers []string
 	group            string
-	topic            string
-	lagThreshold     int64
+	topics           []string
+	lagThreshold     float64
+
+	offsetResetPolicy  kafkaOffsetResetPolicy
+	allowIdleConsumers bool
 
 	// auth
 	authMode kafkaAuthMode
@@ -40,6 +42,10 @@ type kafkaMetadata struct {
 	cert string
 	key  string
 	ca   string
+
+	// oauthbearer
+	oauthTokenEndpointURI string
+	scopes                []string
 }
 
 type kafkaAuthMode string
@@ -51,6 +57,17 @@ const (
 	kafkaAuthModeForSaslScramSha512 kafkaAuthMode = "sasl_scram_sha512"
 	kafkaAuthModeForSaslSSL         kafkaAuthMode = "sasl_ssl"
 	kafkaAuthModeForSaslSSLPlain    kafkaAuthMode = "sasl_ssl_plain"
+	kafkaAuthModeForSaslOAuthbearer kafkaAuthMode = "sasl_oauthbearer"
+	kafkaAuthModeForTLS             kafkaAuthMode = "tls"
+)
+
+type kafkaOffsetResetPolicy string
+
+const (
+	// latest: partitions without committed offset have no lag, the consumer will start with new messages
+	kafkaOffsetResetPolicyLatest kafkaOffsetResetPolicy = "latest"
+	// earliest: partitions without committed offset have all their messages as lag
+	kafkaOffsetResetPolicyEarliest kafkaOffsetResetPolicy = "earliest"
 )
 
 const (
@@ -103,34 +120,60 @@ func parseKafkaMetadata(resolvedEnv, metadata, authParams map[string]string) (ka
 	}
 	meta.group = metadata["consumerGroup"]
 
-	if metadata["topic"] == "" {
-		return meta, errors.New("no topic given")
+	// no topic means all topics the consumer group has committed offsets for
+	if metadata["topic"] != "" {
+		for _, topic := range strings.Split(metadata["topic"], ",") {
+			topic = strings.TrimSpace(topic)
+			if topic != "" {
+				meta.topics = append(meta.topics, topic)
+			}
+		}
 	}
-	meta.topic = metadata["topic"]
 
 	meta.lagThreshold = defaultKafkaLagThreshold
 
 	if val, ok := metadata[lagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
+		t, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return meta, fmt.Errorf("error parsing %s: %s", lagThresholdMetricName, err)
 		}
+		if t <= 0 {
+			return meta, fmt.Errorf("%s must be a positive number", lagThresholdMetricName)
+		}
 		meta.lagThreshold = t
 	}
 
+	meta.offsetResetPolicy = kafkaOffsetResetPolicyEarliest
+	if val, ok := metadata["offsetResetPolicy"]; ok && val != "" {
+		policy := kafkaOffsetResetPolicy(val)
+		if policy != kafkaOffsetResetPolicyEarliest && policy != kafkaOffsetResetPolicyLatest {
+			return meta, fmt.Errorf("err offsetResetPolicy %s given", policy)
+		}
+		meta.offsetResetPolicy = policy
+	}
+
+	meta.allowIdleConsumers = false
+	if val, ok := metadata["allowIdleConsumers"]; ok && val != "" {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing allowIdleConsumers: %s", err)
+		}
+		meta.allowIdleConsumers = t
+	}
+
 	meta.authMode = kafkaAuthModeForNone
 	if val, ok := authParams["authMode"]; ok {
 		val = strings.TrimSpace(val)
 		mode := kafkaAuthMode(val)
 
-		if mode != kafkaAuthModeForNone && mode != kafkaAuthModeForSaslPlaintext && mode != kafkaAuthModeForSaslSSL && mode != kafkaAuthModeForSaslSSLPlain && mode != kafkaAuthModeForSaslScramSha256 && mode != kafkaAuthModeForSaslScramSha512 {
+		if mode != kafkaAuthModeForNone && mode != kafkaAuthModeForSaslPlaintext && mode != kafkaAuthModeForSaslSSL && mode != kafkaAuthModeForSaslSSLPlain && mode != kafkaAuthModeForSaslScramSha256 && mode != kafkaAuthModeForSaslScramSha512 && mode != kafkaAuthModeForSaslOAuthbearer && mode != kafkaAuthModeForTLS {
 			return meta, fmt.Errorf("err auth mode %s given", mode)
 		}
 
 		meta.authMode = mode
 	}
 
-	if meta.authMode != kafkaAuthModeForNone && meta.authMode != kafkaAuthModeForSaslSSL {
+	if meta.authMode != kafkaAuthModeForNone && meta.authMode != kafkaAuthModeForSaslSSL && meta.authMode != kafkaAuthModeForTLS {
 		if authParams["username"] == "" {
 			return meta, errors.New("no username given")
 		}
@@ -159,28 +202,62 @@ func parseKafkaMetadata(resolvedEnv, metadata, authParams map[string]string) (ka
 		meta.key = authParams["key"]
 	}
 
+	if meta.authMode == kafkaAuthModeForTLS {
+		// ca is optional, the system root CAs are used if not given
+		meta.ca = authParams["ca"]
+
+		if authParams["cert"] == "" {
+			return meta, errors.New("no cert given")
+		}
+		meta.cert = authParams["cert"]
+
+		if authParams["key"] == "" {
+			return meta, errors.New("no key given")
+		}
+		meta.key = authParams["key"]
+	}
+
+	if meta.authMode == kafkaAuthModeForSaslOAuthbearer {
+		// username and password are the client id and client secret of the OAuth2 client
+		if authParams["oauthTokenEndpointUri"] == "" {
+			return meta, errors.New("no oauthTokenEndpointUri given")
+		}
+		meta.oauthTokenEndpointURI = strings.TrimSpace(authParams["oauthTokenEndpointUri"])
+
+		if val, ok := authParams["scopes"]; ok && val != "" {
+			for _, scope := range strings.Split(val, ",") {
+				meta.scopes = append(meta.scopes, strings.TrimSpace(scope))
+			}
+		}
+
+		// ca is optional, the system root CAs are used if not given
+		meta.ca = authParams["ca"]
+	}
+
 	return meta, nil
 }
 
 // IsActive determines if we need to scale from zero
 func (s *kafkaScaler) IsActive(ctx context.Context) (bool, error) {
-	partitions, err := s.getPartitions()
+	topicPartitions, err := s.getTopicPartitions()
 	if err != nil {
 		return false, err
 	}
 
-	offsets, err := s.getOffsets(partitions)
+	offsets, err := s.getOffsets(topicPartitions)
 	if err != nil {
 		return false, err
 	}
 
-	for _, partition := range partitions {
-		lag := s.getLagForPartition(partition, offsets)
-		kafkaLog.V(1).Info(fmt.Sprintf("Group %s has a lag of %d for topic %s and partition %d\n", s.metadata.group, lag, s.metadata.topic, partition))
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			lag := s.getLagForPartition(topic, partition, offsets)
+			kafkaLog.V(1).Info(fmt.Sprintf("Group %s has a lag of %d for topic %s and partition %d\n", s.metadata.group, lag, topic, partition))
 
-		// Return as soon as a lag was detected for any partition
-		if lag > 0 {
-			return true, nil
+			// Return as soon as a lag was detected for any partition
+			if lag > 0 {
+				return true, nil
+			}
 		}
 	}
 
@@ -188,6 +265,25 @@ func (s *kafkaScaler) IsActive(ctx context.Context) (bool, error) {
 }
 
 func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
+	config, err := getKafkaConfig(metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := sarama.NewClient(metadata.bootstrapServers, config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating kafka client: %s", err)
+	}
+
+	admin, err := sarama.NewClusterAdmin(metadata.bootstrapServers, config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating kafka admin: %s", err)
+	}
+
+	return client, admin, nil
+}
+
+func getKafkaConfig(metadata kafkaMetadata) (*sarama.Config, error) {
 	config := sarama.NewConfig()
 	config.Version = sarama.V1_0_0_0
 
@@ -210,20 +306,25 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 		config.Net.DialTimeout = 10 * time.Second
 	}
 
-	if metadata.authMode == kafkaAuthModeForSaslSSL {
-		cert, err := tls.X509KeyPair([]byte(metadata.cert), []byte(metadata.key))
+	if metadata.authMode == kafkaAuthModeForSaslSSL || metadata.authMode == kafkaAuthModeForTLS {
+		tlsConfig, err := newTLSConfig(metadata.cert, metadata.key, metadata.ca)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error parse X509KeyPair: %s", err)
+			return nil, err
 		}
 
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(metadata.ca))
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
 
-		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{cert},
-			RootCAs:      caCertPool,
+	if metadata.authMode == kafkaAuthModeForSaslOAuthbearer {
+		tlsConfig, err := newTLSConfig("", "", metadata.ca)
+		if err != nil {
+			return nil, err
 		}
 
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		config.Net.SASL.TokenProvider = newKafkaOAuthTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes)
 		config.Net.TLS.Enable = true
 		config.Net.TLS.Config = tlsConfig
 	}
@@ -243,41 +344,66 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 		config.Net.TLS.Enable = true
 	}
 
-	client, err := sarama.NewClient(metadata.bootstrapServers, config)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error creating kafka client: %s", err)
+	return config, nil
+}
+
+// getTopics returns the configured topics or, if none are configured,
+// all topics the consumer group has committed offsets for
+func (s *kafkaScaler) getTopics() ([]string, error) {
+	if len(s.metadata.topics) > 0 {
+		return s.metadata.topics, nil
 	}
 
-	admin, err := sarama.NewClusterAdmin(metadata.bootstrapServers, config)
+	// listing offsets without partitions returns the offsets for all topics of the group
+	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error creating kafka admin: %s", err)
+		return nil, fmt.Errorf("error listing consumer group offsets: %s", err)
 	}
 
-	return client, admin, nil
+	topics := make([]string, 0, len(offsets.Blocks))
+	for topic := range offsets.Blocks {
+		topics = append(topics, topic)
+	}
+	return topics, nil
 }
 
-func (s *kafkaScaler) getPartitions() ([]int32, error) {
-	topicsMetadata, err := s.admin.DescribeTopics([]string{s.metadata.topic})
+// getTopicPartitions returns the partitions of every topic the scaler is watching
+func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
+	topics, err := s.getTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	topicPartitions := make(map[string][]int32, len(topics))
+	if len(topics) == 0 {
+		return topicPartitions, nil
+	}
+
+	topicsMetadata, err := s.admin.DescribeTopics(topics)
 	if err != nil {
 		return nil, fmt.Errorf("error describing topics: %s", err)
 	}
-	if len(topicsMetadata) != 1 {
-		return nil, fmt.Errorf("expected only 1 topic metadata, got %d", len(topicsMetadata))
+	if len(topicsMetadata) != len(topics) {
+		return nil, fmt.Errorf("expected %d topic metadata, got %d", len(topics), len(topicsMetadata))
 	}
 
-	partitionMetadata := topicsMetadata[0].Partitions
-	partitions := make([]int32, len(partitionMetadata))
-	for i, p := range partitionMetadata {
-		partitions[i] = p.ID
+	for _, topicMetadata := range topicsMetadata {
+		if topicMetadata.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("error describing topic %s: %s", topicMetadata.Name, topicMetadata.Err)
+		}
+
+		partitions := make([]int32, len(topicMetadata.Partitions))
+		for i, p := range topicMetadata.Partitions {
+			partitions[i] = p.ID
+		}
+		topicPartitions[topicMetadata.Name] = partitions
 	}
 
-	return partitions, nil
+	return topicPartitions, nil
 }
 
-func (s *kafkaScaler) getOffsets(partitions []int32) (*sarama.OffsetFetchResponse, error) {
-	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, map[string][]int32{
-		s.metadata.topic: partitions,
-	})
+func (s *kafkaScaler) getOffsets(topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, topicPartitions)
 
 	if err != nil {
 		return nil, fmt.Errorf("error listing consumer group offsets: %s", err)
@@ -286,30 +412,35 @@ func (s *kafkaScaler) getOffsets(partitions []int32) (*sarama.OffsetFetchRespons
 	return offsets, nil
 }
 
-func (s *kafkaScaler) getLagForPartition(partition int32, offsets *sarama.OffsetFetchResponse) int64 {
-	block := offsets.GetBlock(s.metadata.topic, partition)
+func (s *kafkaScaler) getLagForPartition(topic string, partition int32, offsets *sarama.OffsetFetchResponse) int64 {
+	block := offsets.GetBlock(topic, partition)
 	if block == nil {
-		kafkaLog.Error(fmt.Errorf("error finding offset block for topic %s and partition %d", s.metadata.topic, partition), "")
+		kafkaLog.Error(fmt.Errorf("error finding offset block for topic %s and partition %d", topic, partition), "")
 		return 0
 	}
 	consumerOffset := block.Offset
-	latestOffset, err := s.client.GetOffset(s.metadata.topic, partition, sarama.OffsetNewest)
+	if (consumerOffset == sarama.OffsetNewest || consumerOffset == sarama.OffsetOldest) && s.metadata.offsetResetPolicy == kafkaOffsetResetPolicyLatest {
+		// the consumer group has no committed offset and will start with new messages only
+		return 0
+	}
+
+	latestOffset, err := s.client.GetOffset(topic, partition, sarama.OffsetNewest)
 	if err != nil {
-		kafkaLog.Error(err, fmt.Sprintf("error finding latest offset for topic %s and partition %d\n", s.metadata.topic, partition))
+		kafkaLog.Error(err, fmt.Sprintf("error finding latest offset for topic %s and partition %d\n", topic, partition))
 		return 0
 	}
 
-	var lag int64
-	// For now, assume a consumer group that has no committed
-	// offset will read all messages from the topic. This may be
-	// something we want to allow users to configure.
 	if consumerOffset == sarama.OffsetNewest || consumerOffset == sarama.OffsetOldest {
-		lag = latestOffset
-	} else {
-		lag = latestOffset - consumerOffset
+		// the consumer group has no committed offset and will read all messages still available in the partition
+		oldestOffset, err := s.client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			kafkaLog.Error(err, fmt.Sprintf("error finding oldest offset for topic %s and partition %d\n", topic, partition))
+			return 0
+		}
+		return latestOffset - oldestOffset
 	}
 
-	return lag
+	return latestOffset - consumerOffset
 }
 
 // Close closes the kafka admin and client
@@ -331,43 +462,62 @@ func (s *kafkaScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 		{
 			External: &v2beta1.ExternalMetricSource{
 				MetricName:         lagThresholdMetricName,
-				TargetAverageValue: resource.NewQuantity(s.metadata.lagThreshold, resource.DecimalSI),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.lagThreshold*1000), resource.DecimalSI),
 			},
 			Type: kafkaMetricType,
 		},
 	}
 }
 
-//GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+// The lag is reported for each topic separately (labeled with the topic name), the HPA sums the values
 func (s *kafkaScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	partitions, err := s.getPartitions()
+	topicPartitions, err := s.getTopicPartitions()
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
-	offsets, err := s.getOffsets(partitions)
+	offsets, err := s.getOffsets(topicPartitions)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
-	totalLag := int64(0)
-	for _, partition := range partitions {
-		lag := s.getLagForPartition(partition, offsets)
-		totalLag += lag
-	}
+	metrics := []external_metrics.ExternalMetricValue{}
+	totalLag := float64(0)
+	for topic, partitions := range topicPartitions {
+		topicLag := int64(0)
+		for _, partition := range partitions {
+			lag := s.getLagForPartition(topic, partition, offsets)
+			topicLag += lag
+		}
 
-	kafkaLog.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, partitions %v, threshold %v", totalLag, len(partitions), s.metadata.lagThreshold))
+		kafkaLog.V(1).Info(fmt.Sprintf("Kafka scaler: Group %s has a lag of %v for topic %s, partitions %v", s.metadata.group, topicLag, topic, len(partitions)))
 
-	// don't scale out beyond the number of partitions
-	if (totalLag / s.metadata.lagThreshold) > int64(len(partitions)) {
-		totalLag = int64(len(partitions)) * s.metadata.lagThreshold
+		// don't scale out beyond the number of partitions, the extra consumers would be idle
+		lag := float64(topicLag)
+		if !s.metadata.allowIdleConsumers && lag/s.metadata.lagThreshold > float64(len(partitions)) {
+			lag = float64(len(partitions)) * s.metadata.lagThreshold
+		}
+		totalLag += lag
+
+		metrics = append(metrics, external_metrics.ExternalMetricValue{
+			MetricName:   metricName,
+			MetricLabels: map[string]string{"topic": topic},
+			Value:        *resource.NewMilliQuantity(int64(lag*1000), resource.DecimalSI),
+			Timestamp:    metav1.Now(),
+		})
 	}
 
-	metric := external_metrics.ExternalMetricValue{
-		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(totalLag), resource.DecimalSI),
-		Timestamp:  metav1.Now(),
+	kafkaLog.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topics %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
+
+	// the consumer group has no topics yet, report no lag
+	if len(metrics) == 0 {
+		metrics = append(metrics, external_metrics.ExternalMetricValue{
+			MetricName: metricName,
+			Value:      *resource.NewMilliQuantity(0, resource.DecimalSI),
+			Timestamp:  metav1.Now(),
+		})
 	}
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+	return metrics, nil
 }
@@ -1,8 +1,24 @@
 package scalers
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
 )
 
 type parseKafkaMetadataTestData struct {
@@ -11,7 +27,7 @@ type parseKafkaMetadataTestData struct {
 	numBrokers int
 	brokers    []string
 	group      string
-	topic      string
+	topics     []string
 }
 
 // A complete valid metadata example for reference
@@ -33,29 +49,35 @@ var validWithoutAuthParams = map[string]string{}
 
 var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	// failure, no brokerList (deprecated) or bootstrapServers
-	{map[string]string{}, true, 0, nil, "", ""},
+	{map[string]string{}, true, 0, nil, "", nil},
 	// failure, both brokerList (deprecated) and bootstrapServers
-	{map[string]string{"brokerList": "foobar:9092", "bootstrapServers": "foobar:9092"}, true, 0, nil, "", ""},
+	{map[string]string{"brokerList": "foobar:9092", "bootstrapServers": "foobar:9092"}, true, 0, nil, "", nil},
 
 	// tests with brokerList (deprecated)
 	// failure, no consumer group
-	{map[string]string{"brokerList": "foobar:9092"}, true, 1, []string{"foobar:9092"}, "", ""},
-	// failure, no topic
-	{map[string]string{"brokerList": "foobar:9092", "consumerGroup": "my-group"}, true, 1, []string{"foobar:9092"}, "my-group", ""},
+	{map[string]string{"brokerList": "foobar:9092"}, true, 1, []string{"foobar:9092"}, "", nil},
+	// success, no topic means all topics of the consumer group
+	{map[string]string{"brokerList": "foobar:9092", "consumerGroup": "my-group"}, false, 1, []string{"foobar:9092"}, "my-group", nil},
 	// success
-	{map[string]string{"brokerList": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic"},
+	{map[string]string{"brokerList": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}},
 	// success, more brokers
-	{map[string]string{"brokerList": "foo:9092,bar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 2, []string{"foo:9092", "bar:9092"}, "my-group", "my-topic"},
+	{map[string]string{"brokerList": "foo:9092,bar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 2, []string{"foo:9092", "bar:9092"}, "my-group", []string{"my-topic"}},
 
 	// tests with bootstrapServers
 	// failure, no consumer group
-	{map[string]string{"bootstrapServers": "foobar:9092"}, true, 1, []string{"foobar:9092"}, "", ""},
-	// failure, no topic
-	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group"}, true, 1, []string{"foobar:9092"}, "my-group", ""},
+	{map[string]string{"bootstrapServers": "foobar:9092"}, true, 1, []string{"foobar:9092"}, "", nil},
+	// success, no topic means all topics of the consumer group
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group"}, false, 1, []string{"foobar:9092"}, "my-group", nil},
 	// success
-	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic"},
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}},
 	// success, more brokers
-	{map[string]string{"bootstrapServers": "foo:9092,bar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 2, []string{"foo:9092", "bar:9092"}, "my-group", "my-topic"},
+	{map[string]string{"bootstrapServers": "foo:9092,bar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 2, []string{"foo:9092", "bar:9092"}, "my-group", []string{"my-topic"}},
+	// success, multiple topics
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic, other-topic"}, false, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic", "other-topic"}},
+	// failure, lagThreshold of 0
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "0"}, true, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}},
+	// failure, negative lagThreshold
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "-10"}, true, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}},
 }
 
 func TestGetBrokers(t *testing.T) {
@@ -77,8 +99,8 @@ func TestGetBrokers(t *testing.T) {
 		if meta.group != testData.group {
 			t.Errorf("Expected group %s but got %s\n", testData.group, meta.group)
 		}
-		if meta.topic != testData.topic {
-			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+		if !reflect.DeepEqual(testData.topics, meta.topics) {
+			t.Errorf("Expected topics %v but got %v\n", testData.topics, meta.topics)
 		}
 
 		meta, err = parseKafkaMetadata(nil, testData.metadata, validWithoutAuthParams)
@@ -98,8 +120,326 @@ func TestGetBrokers(t *testing.T) {
 		if meta.group != testData.group {
 			t.Errorf("Expected group %s but got %s\n", testData.group, meta.group)
 		}
-		if meta.topic != testData.topic {
-			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+		if !reflect.DeepEqual(testData.topics, meta.topics) {
+			t.Errorf("Expected topics %v but got %v\n", testData.topics, meta.topics)
+		}
+	}
+}
+
+type parseKafkaOffsetsMetadataTestData struct {
+	metadata           map[string]string
+	isError            bool
+	offsetResetPolicy  kafkaOffsetResetPolicy
+	allowIdleConsumers bool
+}
+
+var parseKafkaOffsetsMetadataTestDataset = []parseKafkaOffsetsMetadataTestData{
+	// success, defaults
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, kafkaOffsetResetPolicyEarliest, false},
+	// success, latest
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "offsetResetPolicy": "latest"}, false, kafkaOffsetResetPolicyLatest, false},
+	// failure, unknown offsetResetPolicy
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "offsetResetPolicy": "none"}, true, kafkaOffsetResetPolicyEarliest, false},
+	// success, allowIdleConsumers
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true"}, false, kafkaOffsetResetPolicyEarliest, true},
+	// failure, allowIdleConsumers is not a bool
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "yes please"}, true, kafkaOffsetResetPolicyEarliest, false},
+}
+
+func TestKafkaOffsetsMetadata(t *testing.T) {
+	for _, testData := range parseKafkaOffsetsMetadataTestDataset {
+		meta, err := parseKafkaMetadata(nil, testData.metadata, validWithoutAuthParams)
+
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if meta.offsetResetPolicy != testData.offsetResetPolicy {
+			t.Errorf("Expected offsetResetPolicy %s but got %s\n", testData.offsetResetPolicy, meta.offsetResetPolicy)
+		}
+		if meta.allowIdleConsumers != testData.allowIdleConsumers {
+			t.Errorf("Expected allowIdleConsumers %t but got %t\n", testData.allowIdleConsumers, meta.allowIdleConsumers)
 		}
 	}
 }
+
+func TestKafkaFractionalLagThreshold(t *testing.T) {
+	meta, err := parseKafkaMetadata(nil, map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "0.5"}, validWithoutAuthParams)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	scaler := kafkaScaler{metadata: meta}
+	target := scaler.GetMetricSpecForScaling()[0].External.TargetAverageValue
+	if target.MilliValue() != 500 {
+		t.Errorf("Expected a target of 500m but got %s", target.String())
+	}
+}
+
+type parseKafkaAuthParamsTestData struct {
+	authParams map[string]string
+	isError    bool
+	authMode   kafkaAuthMode
+}
+
+var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
+	// success, tls with ca
+	{map[string]string{"authMode": "tls", "ca": "caaa", "cert": "ceert", "key": "keey"}, false, kafkaAuthModeForTLS},
+	// success, tls without ca
+	{map[string]string{"authMode": "tls", "cert": "ceert", "key": "keey"}, false, kafkaAuthModeForTLS},
+	// failure, tls without cert
+	{map[string]string{"authMode": "tls", "key": "keey"}, true, kafkaAuthModeForTLS},
+	// failure, tls without key
+	{map[string]string{"authMode": "tls", "cert": "ceert"}, true, kafkaAuthModeForTLS},
+	// success, sasl_oauthbearer
+	{map[string]string{"authMode": "sasl_oauthbearer", "username": "client-id", "password": "client-secret", "oauthTokenEndpointUri": "https://oauth.example.com/token", "scopes": "kafka, offsets"}, false, kafkaAuthModeForSaslOAuthbearer},
+	// failure, sasl_oauthbearer without client secret
+	{map[string]string{"authMode": "sasl_oauthbearer", "username": "client-id", "oauthTokenEndpointUri": "https://oauth.example.com/token"}, true, kafkaAuthModeForSaslOAuthbearer},
+	// failure, sasl_oauthbearer without token endpoint
+	{map[string]string{"authMode": "sasl_oauthbearer", "username": "client-id", "password": "client-secret"}, true, kafkaAuthModeForSaslOAuthbearer},
+	// failure, unknown auth mode
+	{map[string]string{"authMode": "kerberos"}, true, ""},
+}
+
+func TestKafkaAuthParams(t *testing.T) {
+	for _, testData := range parseKafkaAuthParamsTestDataset {
+		meta, err := parseKafkaMetadata(nil, validMetadata, testData.authParams)
+
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if err == nil && meta.authMode != testData.authMode {
+			t.Errorf("Expected authMode %s but got %s\n", testData.authMode, meta.authMode)
+		}
+	}
+}
+
+func TestKafkaTLSConfig(t *testing.T) {
+	cert, key := generateTestCertificate(t)
+
+	meta, err := parseKafkaMetadata(nil, validMetadata, map[string]string{"authMode": "tls", "ca": cert, "cert": cert, "key": key})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	config, err := getKafkaConfig(meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !config.Net.TLS.Enable || config.Net.TLS.Config == nil {
+		t.Error("Expected TLS to be enabled")
+	}
+	if len(config.Net.TLS.Config.Certificates) != 1 || config.Net.TLS.Config.RootCAs == nil {
+		t.Error("Expected client certificate and CA in TLS config")
+	}
+	if config.Net.SASL.Enable {
+		t.Error("Expected SASL to be disabled")
+	}
+	if err := config.Validate(); err != nil {
+		t.Error("Expected valid sarama config but got error", err)
+	}
+
+	meta.ca = "invalid"
+	if _, err := getKafkaConfig(meta); err == nil {
+		t.Error("Expected error for invalid ca but got success")
+	}
+
+	meta.ca = cert
+	meta.key = "invalid"
+	if _, err := getKafkaConfig(meta); err == nil {
+		t.Error("Expected error for invalid key but got success")
+	}
+}
+
+// newTestKafkaBroker starts a mock broker behind a TLS listener using the certificate,
+// client certificates signed by it are required when requireClientCert is set
+func newTestKafkaBroker(t *testing.T, cert, key string, requireClientCert bool) *sarama.MockBroker {
+	certificate, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		t.Fatal("Error loading certificate", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{certificate}}
+	if requireClientCert {
+		clientCAs := x509.NewCertPool()
+		clientCAs.AppendCertsFromPEM([]byte(cert))
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Error listening", err)
+	}
+	broker := sarama.NewMockBrokerListener(t, 1, tls.NewListener(listener, tlsConfig))
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest":         sarama.NewMockMetadataResponse(t).SetBroker(broker.Addr(), broker.BrokerID()).SetController(broker.BrokerID()),
+		"SaslHandshakeRequest":    sarama.NewMockSaslHandshakeResponse(t).SetEnabledMechanisms([]string{sarama.SASLTypeOAuth}),
+		"SaslAuthenticateRequest": sarama.NewMockSaslAuthenticateResponse(t),
+	})
+	return broker
+}
+
+func TestKafkaTLSBroker(t *testing.T) {
+	cert, key := generateTestCertificate(t)
+	broker := newTestKafkaBroker(t, cert, key, true)
+	defer broker.Close()
+
+	meta, err := parseKafkaMetadata(nil, map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topic": "my-topic"},
+		map[string]string{"authMode": "tls", "ca": cert, "cert": cert, "key": key})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	// the broker requires a client certificate, so the handshake only succeeds if it is presented
+	client, admin, err := getKafkaClients(meta)
+	if err != nil {
+		t.Fatal("Expected the TLS handshake with the broker to succeed but got error", err)
+	}
+	admin.Close()
+	client.Close()
+}
+
+func TestKafkaOAuthbearerBroker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "broker-token", "token_type": "bearer", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	cert, key := generateTestCertificate(t)
+	broker := newTestKafkaBroker(t, cert, key, false)
+	defer broker.Close()
+
+	meta, err := parseKafkaMetadata(nil, map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topic": "my-topic"},
+		map[string]string{"authMode": "sasl_oauthbearer", "username": "client-id", "password": "client-secret", "oauthTokenEndpointUri": server.URL, "ca": cert})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	client, admin, err := getKafkaClients(meta)
+	if err != nil {
+		t.Fatal("Expected the SASL/OAUTHBEARER handshake with the broker to succeed but got error", err)
+	}
+	admin.Close()
+	client.Close()
+
+	handshakes, authentications := 0, 0
+	for _, rr := range broker.History() {
+		switch request := rr.Request.(type) {
+		case *sarama.SaslHandshakeRequest:
+			handshakes++
+			if request.Mechanism != sarama.SASLTypeOAuth {
+				t.Errorf("Expected SASL mechanism %s but got %s", sarama.SASLTypeOAuth, request.Mechanism)
+			}
+		case *sarama.SaslAuthenticateRequest:
+			authentications++
+			if !strings.Contains(string(request.SaslAuthBytes), "auth=Bearer broker-token") {
+				t.Errorf("Expected the token of the token endpoint but got %q", request.SaslAuthBytes)
+			}
+		}
+	}
+	if handshakes == 0 || authentications == 0 {
+		t.Errorf("Expected SASL handshake and authentication but got %d handshakes and %d authentications", handshakes, authentications)
+	}
+}
+
+func TestKafkaOAuthbearerConfig(t *testing.T) {
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 3600}`, tokenRequests)
+	}))
+	defer server.Close()
+
+	meta, err := parseKafkaMetadata(nil, validMetadata, map[string]string{"authMode": "sasl_oauthbearer", "username": "client-id", "password": "client-secret", "oauthTokenEndpointUri": server.URL})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	config, err := getKafkaConfig(meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !config.Net.SASL.Enable || config.Net.SASL.Mechanism != sarama.SASLTypeOAuth {
+		t.Errorf("Expected SASL mechanism %s but got %s", sarama.SASLTypeOAuth, config.Net.SASL.Mechanism)
+	}
+	if err := config.Validate(); err != nil {
+		t.Error("Expected valid sarama config but got error", err)
+	}
+
+	// the token is fetched once and reused until it expires
+	for i := 0; i < 2; i++ {
+		token, err := config.Net.SASL.TokenProvider.Token()
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if token.Token != "token-1" {
+			t.Errorf("Expected token-1 but got %s", token.Token)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("Expected 1 token request but got %d", tokenRequests)
+	}
+}
+
+func TestKafkaOAuthTokenProviderRefresh(t *testing.T) {
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		w.Header().Set("Content-Type", "application/json")
+		// the token expires right away, so it is refreshed on every call
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 1}`, tokenRequests)
+	}))
+	defer server.Close()
+
+	provider := newKafkaOAuthTokenProvider("client-id", "client-secret", server.URL, nil)
+	for i := 1; i <= 2; i++ {
+		token, err := provider.Token()
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if token.Token != fmt.Sprintf("token-%d", i) {
+			t.Errorf("Expected token-%d but got %s", i, token.Token)
+		}
+	}
+
+	server.Close()
+	if _, err := provider.Token(); err == nil {
+		t.Error("Expected error when token endpoint is not available but got success")
+	}
+}
+
+// generateTestCertificate returns a self-signed certificate and its key in PEM format
+func generateTestCertificate(t *testing.T) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("Error generating key", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "keda-test"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal("Error creating certificate", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal("Error marshalling key", err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(cert), string(key)
+}
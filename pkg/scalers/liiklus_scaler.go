@@ -23,7 +23,7 @@ type liiklusScaler struct {
 }
 
 type liiklusMetadata struct {
-	lagThreshold int64
+	lagThreshold float64
 	address      string
 	topic        string
 	group        string
@@ -31,9 +31,9 @@ type liiklusMetadata struct {
 }
 
 const (
-	defaultLiiklusLagThreshold    int64 = 10
-	liiklusLagThresholdMetricName       = "lagThreshold"
-	liiklusMetricType                   = "External"
+	defaultLiiklusLagThreshold    float64 = 10
+	liiklusLagThresholdMetricName         = "lagThreshold"
+	liiklusMetricType                     = "External"
 )
 
 func NewLiiklusScaler(resolvedEnv map[string]string, metadata map[string]string) (*liiklusScaler, error) {
@@ -61,15 +61,16 @@ func (s *liiklusScaler) GetMetrics(ctx context.Context, metricName string, metri
 	if totalLag, lags, err := s.getLag(ctx); err != nil {
 		return nil, err
 	} else {
-		if totalLag/uint64(s.metadata.lagThreshold) > uint64(len(lags)) {
-			totalLag = uint64(s.metadata.lagThreshold) * uint64(len(lags))
+		lag := float64(totalLag)
+		if lag/s.metadata.lagThreshold > float64(len(lags)) {
+			lag = s.metadata.lagThreshold * float64(len(lags))
 		}
 
 		return []external_metrics.ExternalMetricValue{
 			{
 				MetricName: metricName,
 				Timestamp:  meta_v1.Now(),
-				Value:      *resource.NewQuantity(int64(totalLag), resource.DecimalSI),
+				Value:      *resource.NewMilliQuantity(int64(lag*1000), resource.DecimalSI),
 			},
 		}, nil
 
@@ -82,7 +83,7 @@ func (s *liiklusScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 		{
 			External: &v2beta1.ExternalMetricSource{
 				MetricName:         liiklusLagThresholdMetricName,
-				TargetAverageValue: resource.NewQuantity(s.metadata.lagThreshold, resource.DecimalSI),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.lagThreshold*1000), resource.DecimalSI),
 			},
 			Type: liiklusMetricType,
 		},
@@ -146,11 +147,11 @@ func parseLiiklusMetadata(metadata map[string]string) (*liiklusMetadata, error)
 	lagThreshold := defaultLiiklusLagThreshold
 
 	if val, ok := metadata[liiklusLagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
+		t, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing %s: %s", liiklusLagThresholdMetricName, err)
 		}
-		lagThreshold = int64(t)
+		lagThreshold = t
 	}
 
 	groupVersion := uint32(0)
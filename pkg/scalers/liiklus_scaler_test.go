@@ -16,7 +16,7 @@ type parseLiiklusMetadataTestData struct {
 	liiklusAddress string
 	group          string
 	topic          string
-	threshold      int64
+	threshold      float64
 }
 
 var parseLiiklusMetadataTestDataset = []parseLiiklusMetadataTestData{
@@ -58,7 +58,7 @@ func TestLiiklusParseMetadata(t *testing.T) {
 			continue
 		}
 		if meta.lagThreshold != testData.threshold {
-			t.Errorf("Expected threshold %d but got %d\n", testData.threshold, meta.lagThreshold)
+			t.Errorf("Expected threshold %v but got %v\n", testData.threshold, meta.lagThreshold)
 			continue
 		}
 	}
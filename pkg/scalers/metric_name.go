@@ -0,0 +1,14 @@
+package scalers
+
+import (
+	"regexp"
+	"strings"
+)
+
+var invalidMetricNameCharacters = regexp.MustCompile(`[^A-Za-z0-9-]+`)
+
+// sanitizeMetricName replaces the characters that are not valid in the path of the external metrics API,
+// eg. the dots and # of a JSON path or the / of a CloudWatch dimension value, with a -
+func sanitizeMetricName(name string) string {
+	return strings.Trim(invalidMetricNameCharacters.ReplaceAllString(name, "-"), "-")
+}
@@ -0,0 +1,298 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	metricsAPIDefaultTimeout     = 3000
+	metricsAPIDefaultKeyHeader   = "X-API-KEY"
+	metricsAPIDefaultKeyQueryArg = "api_key"
+)
+
+type metricsAPIAuthMode string
+
+const (
+	metricsAPIAuthModeBearer metricsAPIAuthMode = "bearer"
+	metricsAPIAuthModeBasic  metricsAPIAuthMode = "basic"
+	metricsAPIAuthModeAPIKey metricsAPIAuthMode = "apiKey"
+	metricsAPIAuthModeTLS    metricsAPIAuthMode = "tls"
+)
+
+type metricsAPIScaler struct {
+	metadata   *metricsAPIMetadata
+	httpClient *http.Client
+}
+
+type metricsAPIMetadata struct {
+	url           string
+	valueLocation string
+	targetValue   float64
+	metricName    string
+	timeout       time.Duration
+
+	// authentication
+	enableBearerAuth bool
+	enableBasicAuth  bool
+	enableAPIKeyAuth bool
+	enableTLS        bool
+	bearerToken      string
+	username         string
+	password         string
+	apiKey           string
+	apiKeyMethod     string
+	apiKeyParamName  string
+	cert             string
+	key              string
+	ca               string
+}
+
+var metricsAPILog = logf.Log.WithName("metrics_api_scaler")
+
+// NewMetricsAPIScaler creates a new scaler reading a value from any HTTP endpoint returning JSON
+func NewMetricsAPIScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseMetricsAPIMetadata(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metrics api metadata: %s", err)
+	}
+
+	httpClient := &http.Client{Timeout: meta.timeout}
+	if meta.enableTLS || meta.ca != "" {
+		tlsConfig, err := newTLSConfig(meta.cert, meta.key, meta.ca)
+		if err != nil {
+			return nil, fmt.Errorf("error creating metrics api tls config: %s", err)
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+
+	return &metricsAPIScaler{
+		metadata:   meta,
+		httpClient: httpClient,
+	}, nil
+}
+
+func parseMetricsAPIMetadata(metadata, resolvedEnv, authParams map[string]string) (*metricsAPIMetadata, error) {
+	meta := metricsAPIMetadata{}
+
+	if val, ok := metadata["url"]; ok && val != "" {
+		meta.url = val
+	} else {
+		return nil, fmt.Errorf("no url given")
+	}
+
+	if val, ok := metadata["valueLocation"]; ok && val != "" {
+		meta.valueLocation = val
+	} else {
+		return nil, fmt.Errorf("no valueLocation given")
+	}
+
+	if val, ok := metadata["targetValue"]; ok && val != "" {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("targetValue parsing error %s", err.Error())
+		}
+		meta.targetValue = targetValue
+	} else {
+		return nil, fmt.Errorf("no targetValue given")
+	}
+
+	meta.metricName = fmt.Sprintf("%s-%s", "metrics-api", sanitizeMetricName(meta.valueLocation))
+	if val, ok := metadata["metricName"]; ok && val != "" {
+		meta.metricName = fmt.Sprintf("%s-%s", "metrics-api", sanitizeMetricName(val))
+	}
+
+	meta.timeout = metricsAPIDefaultTimeout * time.Millisecond
+	if val, ok := metadata["timeout"]; ok && val != "" {
+		t, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("timeout parsing error %s", err.Error())
+		}
+		if t <= 0 {
+			return nil, fmt.Errorf("timeout must be greater than 0")
+		}
+		meta.timeout = time.Duration(t) * time.Millisecond
+	}
+
+	if val, ok := metadata["authModes"]; ok && val != "" {
+		for _, mode := range strings.Split(val, ",") {
+			switch metricsAPIAuthMode(strings.TrimSpace(mode)) {
+			case metricsAPIAuthModeBearer:
+				meta.enableBearerAuth = true
+			case metricsAPIAuthModeBasic:
+				meta.enableBasicAuth = true
+			case metricsAPIAuthModeAPIKey:
+				meta.enableAPIKeyAuth = true
+			case metricsAPIAuthModeTLS:
+				meta.enableTLS = true
+			default:
+				return nil, fmt.Errorf("err incorrect value for authModes given: %s", mode)
+			}
+		}
+	}
+
+	authHeaderModes := 0
+	for _, enabled := range []bool{meta.enableBearerAuth, meta.enableBasicAuth, meta.enableAPIKeyAuth} {
+		if enabled {
+			authHeaderModes++
+		}
+	}
+	if authHeaderModes > 1 {
+		return nil, fmt.Errorf("only one of the %s, %s and %s auth modes can be used", metricsAPIAuthModeBearer, metricsAPIAuthModeBasic, metricsAPIAuthModeAPIKey)
+	}
+
+	if meta.enableBearerAuth {
+		if authParams["bearerToken"] == "" {
+			return nil, fmt.Errorf("no bearerToken given")
+		}
+		meta.bearerToken = authParams["bearerToken"]
+	}
+
+	if meta.enableBasicAuth {
+		if authParams["username"] == "" {
+			return nil, fmt.Errorf("no username given")
+		}
+		meta.username = authParams["username"]
+		// password is optional
+		meta.password = authParams["password"]
+	}
+
+	if meta.enableAPIKeyAuth {
+		if authParams["apiKey"] == "" {
+			return nil, fmt.Errorf("no apiKey given")
+		}
+		meta.apiKey = authParams["apiKey"]
+
+		meta.apiKeyMethod = "header"
+		meta.apiKeyParamName = metricsAPIDefaultKeyHeader
+		if val, ok := metadata["method"]; ok && val != "" {
+			switch val {
+			case "header":
+			case "query":
+				meta.apiKeyMethod = val
+				meta.apiKeyParamName = metricsAPIDefaultKeyQueryArg
+			default:
+				return nil, fmt.Errorf("err incorrect value for method given: %s, must be header or query", val)
+			}
+		}
+		if val, ok := metadata["keyParamName"]; ok && val != "" {
+			meta.apiKeyParamName = val
+		}
+	}
+
+	if meta.enableTLS {
+		if authParams["cert"] == "" {
+			return nil, fmt.Errorf("no cert given")
+		}
+		meta.cert = authParams["cert"]
+
+		if authParams["key"] == "" {
+			return nil, fmt.Errorf("no key given")
+		}
+		meta.key = authParams["key"]
+	}
+
+	// a custom CA can be used with any auth mode
+	meta.ca = authParams["ca"]
+
+	return &meta, nil
+}
+
+// getMetricValue requests the url and returns the value found at valueLocation
+func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error) {
+	req, err := http.NewRequest(http.MethodGet, s.metadata.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if s.metadata.enableBearerAuth {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
+	} else if s.metadata.enableBasicAuth {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	} else if s.metadata.enableAPIKeyAuth {
+		if s.metadata.apiKeyMethod == "query" {
+			query := req.URL.Query()
+			query.Set(s.metadata.apiKeyParamName, s.metadata.apiKey)
+			req.URL.RawQuery = query.Encode()
+		} else {
+			req.Header.Set(s.metadata.apiKeyParamName, s.metadata.apiKey)
+		}
+	}
+
+	r, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer r.Body.Close()
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("metrics api returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	return getJSONValue(b, s.metadata.valueLocation)
+}
+
+// Close does nothing for the metrics api scaler
+func (s *metricsAPIScaler) Close() error {
+	return nil
+}
+
+// IsActive returns true if the value at valueLocation is greater than zero
+func (s *metricsAPIScaler) IsActive(ctx context.Context) (bool, error) {
+	val, err := s.getMetricValue(ctx)
+	if err != nil {
+		metricsAPILog.Error(err, "error requesting metrics api")
+		return false, err
+	}
+	return val > 0, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *metricsAPIScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	targetValue := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
+	externalMetric := &v2beta1.ExternalMetricSource{
+		MetricName:         s.metadata.metricName,
+		TargetAverageValue: targetValue,
+	}
+	metricSpec := v2beta1.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2beta1.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *metricsAPIScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	val, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error requesting metrics api: %s", err)
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(int64(val*1000), resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
@@ -0,0 +1,200 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type parseMetricsAPIMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testMetricsAPIMetadata = []parseMetricsAPIMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10"}, map[string]string{}, false},
+	// fractional target and custom timeout
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "0.5", "timeout": "500"}, map[string]string{}, false},
+	// missing url
+	{map[string]string{"valueLocation": "queue.length", "targetValue": "10"}, map[string]string{}, true},
+	// missing valueLocation
+	{map[string]string{"url": "http://backlog:8080/stats", "targetValue": "10"}, map[string]string{}, true},
+	// missing targetValue
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length"}, map[string]string{}, true},
+	// malformed targetValue
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "ten"}, map[string]string{}, true},
+	// malformed timeout
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "timeout": "-1"}, map[string]string{}, true},
+	// bearer auth
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "bearer"}, map[string]string{"bearerToken": "token"}, false},
+	// bearer auth without token
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "bearer"}, map[string]string{}, true},
+	// basic auth without username
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "basic"}, map[string]string{"password": "pass"}, true},
+	// api key in query
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "apiKey", "method": "query"}, map[string]string{"apiKey": "key"}, false},
+	// api key with unknown method
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "apiKey", "method": "cookie"}, map[string]string{"apiKey": "key"}, true},
+	// api key and bearer auth
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "apiKey,bearer"}, map[string]string{"apiKey": "key", "bearerToken": "token"}, true},
+	// tls with bearer auth
+	{map[string]string{"url": "https://backlog:8443/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "tls, bearer"}, map[string]string{"cert": "cert", "key": "key", "bearerToken": "token"}, false},
+	// tls without key
+	{map[string]string{"url": "https://backlog:8443/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "tls"}, map[string]string{"cert": "cert"}, true},
+	// unknown auth mode
+	{map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "10", "authModes": "digest"}, map[string]string{}, true},
+}
+
+func TestParseMetricsAPIMetadata(t *testing.T) {
+	for _, testData := range testMetricsAPIMetadata {
+		_, err := parseMetricsAPIMetadata(testData.metadata, map[string]string{}, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+type metricsAPIAuthTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	check      func(r *http.Request) bool
+}
+
+var testMetricsAPIAuth = []metricsAPIAuthTestData{
+	{
+		map[string]string{"authModes": "bearer"},
+		map[string]string{"bearerToken": "token"},
+		func(r *http.Request) bool { return r.Header.Get("Authorization") == "Bearer token" },
+	},
+	{
+		map[string]string{"authModes": "basic"},
+		map[string]string{"username": "user", "password": "pass"},
+		func(r *http.Request) bool {
+			user, password, ok := r.BasicAuth()
+			return ok && user == "user" && password == "pass"
+		},
+	},
+	{
+		map[string]string{"authModes": "apiKey"},
+		map[string]string{"apiKey": "key"},
+		func(r *http.Request) bool { return r.Header.Get("X-API-KEY") == "key" },
+	},
+	{
+		map[string]string{"authModes": "apiKey", "keyParamName": "X-Token"},
+		map[string]string{"apiKey": "key"},
+		func(r *http.Request) bool { return r.Header.Get("X-Token") == "key" },
+	},
+	{
+		map[string]string{"authModes": "apiKey", "method": "query"},
+		map[string]string{"apiKey": "key"},
+		func(r *http.Request) bool {
+			return r.URL.Query().Get("api_key") == "key" && r.URL.Query().Get("window") == "5m"
+		},
+	},
+}
+
+func TestMetricsAPIGetMetrics(t *testing.T) {
+	for i, testData := range testMetricsAPIAuth {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !testData.check(r) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"queue": {"name": "ingest", "length": "2.5"}}`)
+		}))
+
+		metadata := map[string]string{"url": server.URL + "/stats?window=5m", "valueLocation": "queue.length", "targetValue": "2"}
+		for key, value := range testData.metadata {
+			metadata[key] = value
+		}
+
+		scaler, err := NewMetricsAPIScaler(map[string]string{}, metadata, testData.authParams)
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+
+		metrics, err := scaler.GetMetrics(context.TODO(), "metrics-api-queue-length", nil)
+		if err != nil {
+			t.Errorf("%d: expected success but got error %s", i, err)
+		} else if metrics[0].Value.String() != "2500m" {
+			t.Errorf("%d: expected value 2500m but got %s", i, metrics[0].Value.String())
+		}
+
+		// without credentials the server refuses the request
+		delete(metadata, "authModes")
+		scaler, err = NewMetricsAPIScaler(map[string]string{}, metadata, map[string]string{})
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if _, err := scaler.IsActive(context.TODO()); err == nil {
+			t.Errorf("%d: expected error without credentials but got success", i)
+		}
+
+		server.Close()
+	}
+}
+
+func TestMetricsAPIMetricName(t *testing.T) {
+	metadata := map[string]string{"url": "http://backlog:8080/stats", "valueLocation": "queue.length", "targetValue": "0.5"}
+	scaler, err := NewMetricsAPIScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	target := scaler.GetMetricSpecForScaling()[0].External
+	if target.MetricName != "metrics-api-queue-length" || target.TargetAverageValue.String() != "500m" {
+		t.Errorf("Expected metric metrics-api-queue-length with target 500m but got %s with %s", target.MetricName, target.TargetAverageValue.String())
+	}
+
+	// the # of the array length isn't valid in the path of the external metrics API
+	metadata["valueLocation"] = "items.#"
+	scaler, err = NewMetricsAPIScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "metrics-api-items" {
+		t.Errorf("Expected metric metrics-api-items but got %s", name)
+	}
+
+	metadata["metricName"] = "backlog"
+	scaler, err = NewMetricsAPIScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "metrics-api-backlog" {
+		t.Errorf("Expected metric metrics-api-backlog but got %s", name)
+	}
+}
+
+func TestMetricsAPIContextDeadline(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	metadata := map[string]string{"url": server.URL, "valueLocation": "queue.length", "targetValue": "2", "timeout": "5000"}
+	scaler, err := NewMetricsAPIScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := scaler.IsActive(ctx); err == nil {
+		t.Error("Expected context deadline error but got success")
+	}
+}
@@ -0,0 +1,335 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	mongoDBMetricName        = "mongoDBQueryValue"
+	defaultMongoDBPort       = "27017"
+	defaultMongoDBPassword   = ""
+	mongoDBConnectTimeout    = 5 * time.Second
+	mongoDBClientIdleTimeout = 10 * time.Minute
+)
+
+type mongoDBScaler struct {
+	metadata *mongoDBMetadata
+	client   *mongo.Client
+}
+
+type mongoDBMetadata struct {
+	connectionString string // MongoDB connection string
+	username         string
+	password         string
+	host             string
+	port             string
+	dbName           string
+	collection       string
+	query            string
+	filter           bson.M
+	queryValue       float64
+}
+
+var mongoDBLog = logf.Log.WithName("mongodb_scaler")
+
+// NewMongoDBScaler creates a new MongoDB scaler
+func NewMongoDBScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseMongoDBMetadata(resolvedEnv, metadata, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mongodb metadata: %s", err)
+	}
+
+	client, err := mongoDBClients.get(getMongoDBConnectionString(meta))
+	if err != nil {
+		return nil, fmt.Errorf("error establishing mongodb connection: %s", err)
+	}
+
+	return &mongoDBScaler{
+		metadata: meta,
+		client:   client,
+	}, nil
+}
+
+func parseMongoDBMetadata(resolvedEnv, metadata, authParams map[string]string) (*mongoDBMetadata, error) {
+	meta := mongoDBMetadata{}
+
+	if val, ok := metadata["dbName"]; ok && val != "" {
+		meta.dbName = val
+	} else {
+		return nil, fmt.Errorf("no dbName given")
+	}
+
+	if val, ok := metadata["collection"]; ok && val != "" {
+		meta.collection = val
+	} else {
+		return nil, fmt.Errorf("no collection given")
+	}
+
+	if val, ok := metadata["query"]; ok && val != "" {
+		filter := bson.M{}
+		if err := bson.UnmarshalExtJSON([]byte(val), false, &filter); err != nil {
+			return nil, fmt.Errorf("query parsing error, query must be a JSON document: %s", err)
+		}
+		meta.query = val
+		meta.filter = filter
+	} else {
+		return nil, fmt.Errorf("no query given")
+	}
+
+	if val, ok := metadata["queryValue"]; ok {
+		queryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("queryValue parsing error %s", err.Error())
+		}
+		meta.queryValue = queryValue
+	} else {
+		return nil, fmt.Errorf("no queryValue given")
+	}
+
+	if val, ok := authParams["connectionString"]; ok {
+		meta.connectionString = val
+	} else if val, ok := metadata["connectionString"]; ok {
+		if val, ok := resolvedEnv[val]; ok {
+			meta.connectionString = val
+		} else {
+			return nil, fmt.Errorf("connectionString %s could not be resolved", val)
+		}
+	} else {
+		if val, ok := metadata["host"]; ok && val != "" {
+			meta.host = val
+		} else {
+			return nil, fmt.Errorf("no host given")
+		}
+
+		meta.port = defaultMongoDBPort
+		if val, ok := metadata["port"]; ok && val != "" {
+			if _, err := strconv.Atoi(val); err != nil {
+				return nil, fmt.Errorf("port parsing error %s", err.Error())
+			}
+			meta.port = val
+		}
+
+		// username is optional for MongoDB without authentication
+		if val, ok := authParams["username"]; ok {
+			meta.username = val
+		} else if val, ok := metadata["username"]; ok {
+			meta.username = val
+		}
+
+		meta.password = defaultMongoDBPassword
+		if val, ok := authParams["password"]; ok {
+			meta.password = val
+		} else if val, ok := metadata["password"]; ok && val != "" {
+			if pass, ok := resolvedEnv[val]; ok {
+				meta.password = pass
+			}
+		}
+	}
+
+	return &meta, nil
+}
+
+// getMongoDBConnectionString returns the connection string, building it from the host parts if no connectionString was given.
+// The dbName is the database of the built connection string, so users defined on it can authenticate.
+func getMongoDBConnectionString(meta *mongoDBMetadata) string {
+	if meta.connectionString != "" {
+		return meta.connectionString
+	}
+
+	connectionURL := &url.URL{
+		Scheme: "mongodb",
+		Host:   net.JoinHostPort(meta.host, meta.port),
+		Path:   "/" + meta.dbName,
+	}
+	if meta.username != "" {
+		connectionURL.User = url.UserPassword(meta.username, meta.password)
+	}
+	return connectionURL.String()
+}
+
+// mongoDBClientPool keeps one client per connection string, so the scalers rebuilt on every poll
+// share their connections instead of connecting and pinging again.
+// Scalers hand their client back with release, a client no scaler used for mongoDBClientIdleTimeout is disconnected.
+type mongoDBClientPool struct {
+	mutex   sync.Mutex
+	clients map[string]*mongoDBClient
+	connect func(connectionString string) (*mongo.Client, error)
+}
+
+type mongoDBClient struct {
+	client *mongo.Client
+	err    error
+	// ready is closed once the client is connected and pinged, client and err are set by then
+	ready    chan struct{}
+	refs     int
+	lastUsed time.Time
+}
+
+var mongoDBClients = &mongoDBClientPool{clients: map[string]*mongoDBClient{}, connect: newMongoDBClient}
+
+// get returns the pooled client for the connection string, connecting on first use outside of the pool lock
+func (p *mongoDBClientPool) get(connectionString string) (*mongo.Client, error) {
+	p.mutex.Lock()
+	p.closeIdle(time.Now())
+	conn, ok := p.clients[connectionString]
+	if !ok {
+		conn = &mongoDBClient{ready: make(chan struct{})}
+		p.clients[connectionString] = conn
+	}
+	conn.refs++
+	p.mutex.Unlock()
+
+	if ok {
+		<-conn.ready
+	} else {
+		conn.client, conn.err = p.connect(connectionString)
+		close(conn.ready)
+	}
+
+	if conn.err != nil {
+		// the failed client is dropped, the next scaler tries again
+		p.mutex.Lock()
+		conn.refs--
+		if p.clients[connectionString] == conn {
+			delete(p.clients, connectionString)
+		}
+		p.mutex.Unlock()
+		return nil, conn.err
+	}
+
+	return conn.client, nil
+}
+
+// release hands back a client returned by get
+func (p *mongoDBClientPool) release(client *mongo.Client) {
+	if client == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, conn := range p.clients {
+		select {
+		case <-conn.ready:
+		default:
+			// still connecting, client isn't set yet
+			continue
+		}
+		if conn.client == client {
+			conn.refs--
+			conn.lastUsed = time.Now()
+			return
+		}
+	}
+}
+
+// closeIdle disconnects the clients not used by any scaler for mongoDBClientIdleTimeout, the pool lock must be held
+func (p *mongoDBClientPool) closeIdle(now time.Time) {
+	for key, conn := range p.clients {
+		if conn.refs == 0 && now.Sub(conn.lastUsed) > mongoDBClientIdleTimeout {
+			delete(p.clients, key)
+			// disconnecting waits for the in progress operations, don't hold the pool lock meanwhile
+			go func(client *mongo.Client) {
+				ctx, cancel := context.WithTimeout(context.Background(), mongoDBConnectTimeout)
+				defer cancel()
+				if err := client.Disconnect(ctx); err != nil {
+					mongoDBLog.Error(err, "Error closing mongodb connection")
+				}
+			}(conn.client)
+		}
+	}
+}
+
+// newMongoDBClient connects to MongoDB and checks the connection
+func newMongoDBClient(connectionString string) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoDBConnectTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
+	if err != nil {
+		mongoDBLog.Error(err, fmt.Sprintf("Found error when connecting to mongodb: %s", err))
+		return nil, err
+	}
+
+	if err = client.Ping(ctx, readpref.Primary()); err != nil {
+		mongoDBLog.Error(err, fmt.Sprintf("Found error when pinging mongodb: %s", err))
+		client.Disconnect(ctx)
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Close hands the client back to the pool, it is shared by the scalers with the same connection string
+func (s *mongoDBScaler) Close() error {
+	mongoDBClients.release(s.client)
+	return nil
+}
+
+// IsActive returns true if there are documents matching the query
+func (s *mongoDBScaler) IsActive(ctx context.Context) (bool, error) {
+	count, err := s.getDocumentsCount(ctx)
+	if err != nil {
+		mongoDBLog.Error(err, fmt.Sprintf("Error inspecting mongodb: %s", err))
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// getDocumentsCount returns the number of documents in the collection matching the query
+func (s *mongoDBScaler) getDocumentsCount(ctx context.Context) (int64, error) {
+	collection := s.client.Database(s.metadata.dbName).Collection(s.metadata.collection)
+	count, err := collection.CountDocuments(ctx, s.metadata.filter)
+	if err != nil {
+		mongoDBLog.Error(err, fmt.Sprintf("Could not query mongodb collection %s: %s", s.metadata.collection, err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *mongoDBScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	targetQueryValue := resource.NewMilliQuantity(int64(s.metadata.queryValue*1000), resource.DecimalSI)
+	externalMetric := &v2beta1.ExternalMetricSource{
+		MetricName:         mongoDBMetricName,
+		TargetAverageValue: targetQueryValue,
+	}
+	metricSpec := v2beta1.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2beta1.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *mongoDBScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	count, err := s.getDocumentsCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting mongodb: %s", err)
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: mongoDBMetricName,
+		Value:      *resource.NewMilliQuantity(count*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
@@ -0,0 +1,217 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	// mssql driver required for this scaler
+	_ "github.com/denisenkom/go-mssqldb"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	mssqlMetricName      = "mssqlQueryValue"
+	defaultMSSQLPassword = ""
+)
+
+type mssqlScaler struct {
+	metadata   *mssqlMetadata
+	connection *sql.DB
+}
+
+type mssqlMetadata struct {
+	connectionString string // Database connection string
+	username         string
+	password         string
+	host             string
+	port             string
+	database         string
+	query            string
+	targetValue      float64
+	queryTimeout     time.Duration
+}
+
+var mssqlLog = logf.Log.WithName("mssql_scaler")
+
+// NewMSSQLScaler creates a new Microsoft SQL Server scaler
+func NewMSSQLScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseMSSQLMetadata(resolvedEnv, metadata, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mssql metadata: %s", err)
+	}
+
+	conn, err := sqlConnections.get("sqlserver", getMSSQLConnectionString(meta))
+	if err != nil {
+		mssqlLog.Error(err, fmt.Sprintf("Found error when connecting to mssql: %s", err))
+		return nil, fmt.Errorf("error establishing mssql connection: %s", err)
+	}
+
+	return &mssqlScaler{
+		metadata:   meta,
+		connection: conn,
+	}, nil
+}
+
+func parseMSSQLMetadata(resolvedEnv, metadata, authParams map[string]string) (*mssqlMetadata, error) {
+	meta := mssqlMetadata{}
+
+	if val, ok := metadata["query"]; ok && val != "" {
+		meta.query = val
+	} else {
+		return nil, fmt.Errorf("no query given")
+	}
+
+	if val, ok := metadata["targetValue"]; ok {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("targetValue parsing error %s", err.Error())
+		}
+		meta.targetValue = targetValue
+	} else {
+		return nil, fmt.Errorf("no targetValue given")
+	}
+
+	queryTimeout, err := parseSQLQueryTimeout(metadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.queryTimeout = queryTimeout
+
+	if val, ok := authParams["connectionString"]; ok {
+		meta.connectionString = val
+	} else if val, ok := metadata["connectionString"]; ok {
+		if val, ok := resolvedEnv[val]; ok {
+			meta.connectionString = val
+		} else {
+			return nil, fmt.Errorf("connectionString %s could not be resolved", val)
+		}
+	} else {
+		if val, ok := metadata["host"]; ok && val != "" {
+			meta.host = val
+		} else {
+			return nil, fmt.Errorf("no host given")
+		}
+
+		// port is optional, the driver defaults to 1433
+		if val, ok := metadata["port"]; ok && val != "" {
+			if _, err := strconv.Atoi(val); err != nil {
+				return nil, fmt.Errorf("port parsing error %s", err.Error())
+			}
+			meta.port = val
+		}
+
+		if val, ok := authParams["username"]; ok && val != "" {
+			meta.username = val
+		} else if val, ok := metadata["username"]; ok && val != "" {
+			meta.username = val
+		} else {
+			return nil, fmt.Errorf("no username given")
+		}
+
+		// database is optional, the login's default database is used otherwise
+		if val, ok := metadata["database"]; ok {
+			meta.database = val
+		}
+
+		meta.password = defaultMSSQLPassword
+		if val, ok := authParams["password"]; ok {
+			meta.password = val
+		} else if val, ok := metadata["password"]; ok && val != "" {
+			if pass, ok := resolvedEnv[val]; ok {
+				meta.password = pass
+			}
+		}
+	}
+
+	return &meta, nil
+}
+
+// getMSSQLConnectionString returns the connection string, building it in URL format if no connectionString was given
+func getMSSQLConnectionString(meta *mssqlMetadata) string {
+	if meta.connectionString != "" {
+		return meta.connectionString
+	}
+
+	host := meta.host
+	if meta.port != "" {
+		host = net.JoinHostPort(meta.host, meta.port)
+	}
+
+	query := url.Values{}
+	if meta.database != "" {
+		query.Add("database", meta.database)
+	}
+
+	connectionURL := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(meta.username, meta.password),
+		Host:     host,
+		RawQuery: query.Encode(),
+	}
+	return connectionURL.String()
+}
+
+// Close hands the connection back to the pool, it is shared by the scalers with the same connection settings
+func (s *mssqlScaler) Close() error {
+	sqlConnections.release(s.connection)
+	return nil
+}
+
+// IsActive returns true if the query result is greater than zero
+func (s *mssqlScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		mssqlLog.Error(err, fmt.Sprintf("Error inspecting mssql: %s", err))
+		return false, err
+	}
+	return value > 0, nil
+}
+
+// getQueryResult returns result of the scaler query, NULL and no rows are returned as 0
+func (s *mssqlScaler) getQueryResult(ctx context.Context) (float64, error) {
+	value, err := getSQLQueryResult(ctx, s.connection, s.metadata.query, s.metadata.queryTimeout)
+	if err != nil {
+		mssqlLog.Error(err, fmt.Sprintf("Could not query mssql database: %s", err))
+		return 0, err
+	}
+	return value, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *mssqlScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	targetValue := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
+	externalMetric := &v2beta1.ExternalMetricSource{
+		MetricName:         mssqlMetricName,
+		TargetAverageValue: targetValue,
+	}
+	metricSpec := v2beta1.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2beta1.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *mssqlScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	num, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting mssql: %s", err)
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: mssqlMetricName,
+		Value:      *resource.NewMilliQuantity(int64(num*1000), resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
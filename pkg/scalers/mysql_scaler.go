@@ -2,7 +2,9 @@ package scalers
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"github.com/go-sql-driver/mysql"
 	"k8s.io/api/autoscaling/v2beta1"
@@ -12,6 +14,7 @@ import (
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"strconv"
+	"time"
 )
 
 const (
@@ -32,7 +35,13 @@ type mySQLMetadata struct {
 	port             string
 	dbName           string
 	query            string
-	queryValue       int
+	queryValue       float64
+	queryTimeout     time.Duration
+	// TLS, registered with the MySQL driver under tlsConfigName
+	tlsConfigName string
+	ca            string
+	cert          string
+	key           string
 }
 
 var mySQLLog = logf.Log.WithName("mysql_scaler")
@@ -64,7 +73,7 @@ func parseMySQLMetadata(resolvedEnv, metadata, authParams map[string]string) (*m
 	}
 
 	if val, ok := metadata["queryValue"]; ok {
-		queryValue, err := strconv.Atoi(val)
+		queryValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("queryValue parsing error %s", err.Error())
 		}
@@ -73,6 +82,24 @@ func parseMySQLMetadata(resolvedEnv, metadata, authParams map[string]string) (*m
 		return nil, fmt.Errorf("no queryValue given")
 	}
 
+	queryTimeout, err := parseSQLQueryTimeout(metadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.queryTimeout = queryTimeout
+
+	meta.ca = authParams["ca"]
+	meta.cert = authParams["cert"]
+	meta.key = authParams["key"]
+	if (meta.cert == "") != (meta.key == "") {
+		return nil, fmt.Errorf("both cert and key must be given for client certificate authentication")
+	}
+	if meta.ca != "" || meta.cert != "" {
+		// the config is named after its content, so scalers with the same TLS settings share it
+		hash := sha256.Sum256([]byte(meta.ca + "\x00" + meta.cert + "\x00" + meta.key))
+		meta.tlsConfigName = "keda-" + hex.EncodeToString(hash[:8])
+	}
+
 	if val, ok := authParams["connectionString"]; ok {
 		meta.connectionString = val
 	} else if val, ok := metadata["connectionString"]; ok {
@@ -113,6 +140,15 @@ func parseMySQLMetadata(resolvedEnv, metadata, authParams map[string]string) (*m
 		}
 	}
 
+	if meta.connectionString != "" && meta.tlsConfigName != "" {
+		config, err := mysql.ParseDSN(meta.connectionString)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing connectionString: %s", err)
+		}
+		config.TLSConfig = meta.tlsConfigName
+		meta.connectionString = config.FormatDSN()
+	}
+
 	return &meta, nil
 }
 
@@ -130,6 +166,7 @@ func metadataToConnectionStr(meta *mySQLMetadata) string {
 		config.Passwd = meta.password
 		config.User = meta.username
 		config.Net = "tcp"
+		config.TLSConfig = meta.tlsConfigName
 		connStr = config.FormatDSN()
 	}
 	return connStr
@@ -137,33 +174,34 @@ func metadataToConnectionStr(meta *mySQLMetadata) string {
 
 // newMySQLConnection creates MySQL db connection
 func newMySQLConnection(meta *mySQLMetadata) (*sql.DB, error) {
-	connStr := metadataToConnectionStr(meta)
-	db, err := sql.Open("mysql", connStr)
-	if err != nil {
-		mySQLLog.Error(err, fmt.Sprintf("Found error when opening connection: %s", err))
-		return nil, err
+	if meta.tlsConfigName != "" {
+		tlsConfig, err := newTLSConfig(meta.cert, meta.key, meta.ca)
+		if err != nil {
+			return nil, err
+		}
+		if err := mysql.RegisterTLSConfig(meta.tlsConfigName, tlsConfig); err != nil {
+			return nil, fmt.Errorf("error registering TLS config: %s", err)
+		}
 	}
-	err = db.Ping()
+
+	connStr := metadataToConnectionStr(meta)
+	db, err := sqlConnections.get("mysql", connStr)
 	if err != nil {
-		mySQLLog.Error(err, fmt.Sprintf("Found error when pinging database: %s", err))
+		mySQLLog.Error(err, fmt.Sprintf("Found error when connecting to MySQL: %s", err))
 		return nil, err
 	}
 	return db, nil
 }
 
-// Close disposes of MySQL connections
+// Close hands the connection back to the pool, it is shared by the scalers with the same connection settings
 func (s *mySQLScaler) Close() error {
-	err := s.connection.Close()
-	if err != nil {
-		mySQLLog.Error(err, "Error closing MySQL connection")
-		return err
-	}
+	sqlConnections.release(s.connection)
 	return nil
 }
 
 // IsActive returns true if there are pending messages to be processed
 func (s *mySQLScaler) IsActive(ctx context.Context) (bool, error) {
-	messages, err := s.getQueryResult()
+	messages, err := s.getQueryResult(ctx)
 	if err != nil {
 		mySQLLog.Error(err, fmt.Sprintf("Error inspecting MySQL: %s", err))
 		return false, err
@@ -171,10 +209,9 @@ func (s *mySQLScaler) IsActive(ctx context.Context) (bool, error) {
 	return messages > 0, nil
 }
 
-// getQueryResult returns result of the scaler query
-func (s *mySQLScaler) getQueryResult() (int, error) {
-	var value int
-	err := s.connection.QueryRow(s.metadata.query).Scan(&value)
+// getQueryResult returns result of the scaler query, NULL and no rows are returned as 0
+func (s *mySQLScaler) getQueryResult(ctx context.Context) (float64, error) {
+	value, err := getSQLQueryResult(ctx, s.connection, s.metadata.query, s.metadata.queryTimeout)
 	if err != nil {
 		mySQLLog.Error(err, fmt.Sprintf("Could not query MySQL database: %s", err))
 		return 0, err
@@ -184,7 +221,7 @@ func (s *mySQLScaler) getQueryResult() (int, error) {
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *mySQLScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetQueryValue := resource.NewQuantity(int64(s.metadata.queryValue), resource.DecimalSI)
+	targetQueryValue := resource.NewMilliQuantity(int64(s.metadata.queryValue*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{
 		MetricName:         mySQLMetricName,
 		TargetAverageValue: targetQueryValue,
@@ -197,14 +234,14 @@ func (s *mySQLScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *mySQLScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	num, err := s.getQueryResult()
+	num, err := s.getQueryResult(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting MySQL: %s", err)
 	}
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: mySQLMetricName,
-		Value:      *resource.NewQuantity(int64(num), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(num*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
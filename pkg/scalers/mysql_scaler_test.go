@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -10,17 +11,17 @@ var testMySQLResolvedEnv = map[string]string{
 }
 
 type parseMySQLMetadataTestData struct {
-	metdadata   map[string] string
+	metdadata   map[string]string
 	raisesError bool
 }
 
 var testMySQLMetdata = []parseMySQLMetadataTestData{
 	// No metadata
-	{metdadata: map[string]string{}, raisesError:true},
+	{metdadata: map[string]string{}, raisesError: true},
 	// connectionString
-	{metdadata: map[string]string{"query": "query", "queryValue": "12", "connectionString": "test_value"}, raisesError:false},
+	{metdadata: map[string]string{"query": "query", "queryValue": "12", "connectionString": "test_value"}, raisesError: false},
 	// Params instead of conn str
-	{metdadata: map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "test_port", "username": "test_username", "password": "test_password", "dbName": "test_dbname"}, raisesError:false},
+	{metdadata: map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "test_port", "username": "test_username", "password": "test_password", "dbName": "test_dbname"}, raisesError: false},
 }
 
 func TestParseMySQLMetadata(t *testing.T) {
@@ -56,3 +57,52 @@ func TestMetadataToConnectionStrBuildNew(t *testing.T) {
 	}
 }
 
+type parseMySQLTLSTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	raisesError bool
+	enableTLS   bool
+}
+
+var testMySQLTLSMetadata = []parseMySQLTLSTestData{
+	// no TLS
+	{map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "3306", "username": "test_username", "dbName": "test_dbname"}, map[string]string{}, false, false},
+	// CA only
+	{map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "3306", "username": "test_username", "dbName": "test_dbname"}, map[string]string{"ca": "ca"}, false, true},
+	// client certificate
+	{map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "3306", "username": "test_username", "dbName": "test_dbname"}, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}, false, true},
+	// cert without key
+	{map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "3306", "username": "test_username", "dbName": "test_dbname"}, map[string]string{"cert": "cert"}, true, false},
+	// connection string
+	{map[string]string{"query": "query", "queryValue": "12"}, map[string]string{"connectionString": "test_username:pass@tcp(test_host:3306)/test_dbname", "ca": "ca"}, false, true},
+	// malformed connection string
+	{map[string]string{"query": "query", "queryValue": "12"}, map[string]string{"connectionString": "test_conn_str", "ca": "ca"}, true, false},
+	// query timeout
+	{map[string]string{"query": "query", "queryValue": "0.5", "connectionString": "MYSQL_CONN_STR", "queryTimeout": "500"}, map[string]string{}, false, false},
+	// malformed query timeout
+	{map[string]string{"query": "query", "queryValue": "12", "connectionString": "MYSQL_CONN_STR", "queryTimeout": "fast"}, map[string]string{}, true, false},
+}
+
+func TestParseMySQLTLSMetadata(t *testing.T) {
+	for _, testData := range testMySQLTLSMetadata {
+		meta, err := parseMySQLMetadata(testMySQLResolvedEnv, testData.metadata, testData.authParams)
+		if err != nil && !testData.raisesError {
+			t.Error("Expected success but got error", err)
+		}
+		if err == nil && testData.raisesError {
+			t.Error("Expected error but got success")
+		}
+		if err != nil {
+			continue
+		}
+
+		connStr := metadataToConnectionStr(meta)
+		hasTLS := strings.Contains(connStr, "tls="+meta.tlsConfigName)
+		if testData.enableTLS && (meta.tlsConfigName == "" || !hasTLS) {
+			t.Errorf("Expected TLS config in connection string but got %s", connStr)
+		}
+		if !testData.enableTLS && meta.tlsConfigName != "" {
+			t.Errorf("Expected no TLS config but got %s", meta.tlsConfigName)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultJetStreamLagThreshold = 10
+	defaultJetStreamAccount      = "$G"
+	jetStreamHTTPTimeout         = 3 * time.Second
+)
+
+type jetStreamEndpointResponse struct {
+	MetaCluster    jetStreamMetaCluster     `json:"meta_cluster"`
+	AccountDetails []jetStreamAccountDetail `json:"account_details"`
+}
+
+type jetStreamMetaCluster struct {
+	Name        string `json:"name"`
+	Leader      string `json:"leader"`
+	ClusterSize int    `json:"cluster_size"`
+}
+
+type jetStreamAccountDetail struct {
+	Name    string                  `json:"name"`
+	Streams []jetStreamStreamDetail `json:"stream_detail"`
+}
+
+type jetStreamStreamDetail struct {
+	Name      string                    `json:"name"`
+	Cluster   jetStreamClusterInfo      `json:"cluster"`
+	Consumers []jetStreamConsumerDetail `json:"consumer_detail"`
+}
+
+type jetStreamConsumerDetail struct {
+	StreamName     string               `json:"stream_name"`
+	Name           string               `json:"name"`
+	NumAckPending  int64                `json:"num_ack_pending"`
+	NumRedelivered int64                `json:"num_redelivered"`
+	NumWaiting     int64                `json:"num_waiting"`
+	NumPending     int64                `json:"num_pending"`
+	Cluster        jetStreamClusterInfo `json:"cluster"`
+}
+
+type jetStreamClusterInfo struct {
+	Name   string `json:"name"`
+	Leader string `json:"leader"`
+}
+
+type natsJetStreamScaler struct {
+	metadata   natsJetStreamMetadata
+	httpClient *http.Client
+}
+
+type natsJetStreamMetadata struct {
+	monitoringEndpoint string
+	// leaderMonitoringEndpoint is the monitoring endpoint of the leader of a clustered consumer,
+	// %s is replaced by the server name of the leader. The leader isn't queried when it is empty.
+	leaderMonitoringEndpoint string
+	account                  string
+	stream                   string
+	consumer                 string
+	lagThreshold             float64
+}
+
+var natsJetStreamLog = logf.Log.WithName("nats_jetstream_scaler")
+
+// NewNATSJetStreamScaler creates a new natsJetStreamScaler
+func NewNATSJetStreamScaler(resolvedEnv, metadata map[string]string) (Scaler, error) {
+	meta, err := parseNATSJetStreamMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing nats jetstream metadata: %s", err)
+	}
+
+	return &natsJetStreamScaler{
+		metadata:   meta,
+		httpClient: &http.Client{Timeout: jetStreamHTTPTimeout},
+	}, nil
+}
+
+func parseNATSJetStreamMetadata(metadata map[string]string) (natsJetStreamMetadata, error) {
+	meta := natsJetStreamMetadata{}
+
+	if metadata["natsServerMonitoringEndpoint"] == "" {
+		return meta, errors.New("no monitoring endpoint given")
+	}
+	meta.monitoringEndpoint = metadata["natsServerMonitoringEndpoint"]
+
+	if val, ok := metadata["useHeadlessServiceForLeader"]; ok && val != "" {
+		useHeadlessService, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing useHeadlessServiceForLeader: %s", err)
+		}
+		if useHeadlessService {
+			// the servers of a StatefulSet are reachable as <pod>.<headless service>
+			meta.leaderMonitoringEndpoint = "%s." + meta.monitoringEndpoint
+		}
+	}
+	if val := metadata["leaderMonitoringEndpoint"]; val != "" {
+		if meta.leaderMonitoringEndpoint != "" {
+			return meta, errors.New("useHeadlessServiceForLeader and leaderMonitoringEndpoint can't be used together")
+		}
+		if strings.Count(val, "%s") != 1 {
+			return meta, errors.New("leaderMonitoringEndpoint must contain %s once, it is replaced by the server name of the leader")
+		}
+		meta.leaderMonitoringEndpoint = val
+	}
+
+	meta.account = defaultJetStreamAccount
+	if metadata["account"] != "" {
+		meta.account = metadata["account"]
+	}
+
+	if metadata["stream"] == "" {
+		return meta, errors.New("no stream given")
+	}
+	meta.stream = metadata["stream"]
+
+	if metadata["consumer"] == "" {
+		return meta, errors.New("no consumer given")
+	}
+	meta.consumer = metadata["consumer"]
+
+	meta.lagThreshold = defaultJetStreamLagThreshold
+	if val, ok := metadata[lagThresholdMetricName]; ok {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing %s: %s", lagThresholdMetricName, err)
+		}
+		meta.lagThreshold = t
+	}
+
+	return meta, nil
+}
+
+// getJetStreamEndpoint returns the /jsz url of the monitoring endpoint, or of the leader with the given server name
+func (s *natsJetStreamScaler) getJetStreamEndpoint(serverName string) string {
+	host := s.metadata.monitoringEndpoint
+	if serverName != "" {
+		host = strings.Replace(s.metadata.leaderMonitoringEndpoint, "%s", serverName, 1)
+	}
+	return fmt.Sprintf("http://%s/jsz?acc=%s&consumers=true&config=true", host, url.QueryEscape(s.metadata.account))
+}
+
+func (s *natsJetStreamScaler) getJetStreamInfo(ctx context.Context, endpoint string) (*jetStreamEndpointResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to access the nats jetstream monitoring endpoint %s: %s", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nats jetstream monitoring endpoint %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	info := &jetStreamEndpointResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// findConsumer returns the consumer details of the configured stream and consumer
+func (s *natsJetStreamScaler) findConsumer(info *jetStreamEndpointResponse) (*jetStreamStreamDetail, *jetStreamConsumerDetail, error) {
+	for _, account := range info.AccountDetails {
+		if account.Name != s.metadata.account {
+			continue
+		}
+		for i, stream := range account.Streams {
+			if stream.Name != s.metadata.stream {
+				continue
+			}
+			for j, consumer := range stream.Consumers {
+				if consumer.Name == s.metadata.consumer {
+					return &account.Streams[i], &account.Streams[i].Consumers[j], nil
+				}
+			}
+			return nil, nil, fmt.Errorf("consumer %s not found in stream %s", s.metadata.consumer, s.metadata.stream)
+		}
+		return nil, nil, fmt.Errorf("stream %s not found in account %s", s.metadata.stream, s.metadata.account)
+	}
+	return nil, nil, fmt.Errorf("account %s not found", s.metadata.account)
+}
+
+// getConsumerInfo returns the consumer details, in a cluster they're read from the server leading the consumer
+// as the other servers may report outdated values. The values of the monitoring endpoint are used when the leader
+// isn't configured or can't be reached.
+func (s *natsJetStreamScaler) getConsumerInfo(ctx context.Context) (*jetStreamConsumerDetail, error) {
+	info, err := s.getJetStreamInfo(ctx, s.getJetStreamEndpoint(""))
+	if err != nil {
+		return nil, err
+	}
+
+	stream, consumer, err := s.findConsumer(info)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.MetaCluster.ClusterSize <= 1 || s.metadata.leaderMonitoringEndpoint == "" {
+		return consumer, nil
+	}
+
+	leader := consumer.Cluster.Leader
+	if leader == "" {
+		leader = stream.Cluster.Leader
+	}
+	if leader == "" {
+		natsJetStreamLog.Info("No leader found for the nats jetstream consumer, using the monitoring endpoint values", "stream", s.metadata.stream, "consumer", s.metadata.consumer)
+		return consumer, nil
+	}
+
+	leaderInfo, err := s.getJetStreamInfo(ctx, s.getJetStreamEndpoint(leader))
+	if err != nil {
+		natsJetStreamLog.V(1).Info("Unable to reach the nats jetstream consumer leader, using the monitoring endpoint values", "leader", leader, "error", err.Error())
+		return consumer, nil
+	}
+	_, leaderConsumer, err := s.findConsumer(leaderInfo)
+	if err != nil {
+		natsJetStreamLog.V(1).Info("Consumer not found on the nats jetstream consumer leader, using the monitoring endpoint values", "leader", leader, "error", err.Error())
+		return consumer, nil
+	}
+	return leaderConsumer, nil
+}
+
+func (s *natsJetStreamScaler) getMaxMsgLag(consumer *jetStreamConsumerDetail) int64 {
+	return consumer.NumPending + consumer.NumAckPending
+}
+
+// IsActive determines if we need to scale from zero
+func (s *natsJetStreamScaler) IsActive(ctx context.Context) (bool, error) {
+	consumer, err := s.getConsumerInfo(ctx)
+	if err != nil {
+		natsJetStreamLog.Error(err, "Unable to get the nats jetstream consumer info", "stream", s.metadata.stream, "consumer", s.metadata.consumer)
+		return false, err
+	}
+
+	return s.getMaxMsgLag(consumer) > 0, nil
+}
+
+func (s *natsJetStreamScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	return []v2beta1.MetricSpec{
+		{
+			External: &v2beta1.ExternalMetricSource{
+				MetricName:         fmt.Sprintf("%s-%s-%s", "nats-jetstream", s.metadata.stream, s.metadata.consumer),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.lagThreshold*1000), resource.DecimalSI),
+			},
+			Type: externalMetricType,
+		},
+	}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *natsJetStreamScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	consumer, err := s.getConsumerInfo(ctx)
+	if err != nil {
+		natsJetStreamLog.Error(err, "Unable to get the nats jetstream consumer info", "stream", s.metadata.stream, "consumer", s.metadata.consumer)
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	totalLag := s.getMaxMsgLag(consumer)
+	natsJetStreamLog.V(1).Info("NATS JetStream Scaler", "stream", s.metadata.stream, "consumer", s.metadata.consumer, "totalLag", totalLag)
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(totalLag*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// Close does nothing for the nats jetstream scaler
+func (s *natsJetStreamScaler) Close() error {
+	return nil
+}
@@ -0,0 +1,138 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type parseNATSJetStreamMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+var testNATSJetStreamMetadata = []parseNATSJetStreamMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// all good
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing"}, false},
+	// all good with account and lagThreshold
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "app", "stream": "orders", "consumer": "billing", "lagThreshold": "50"}, false},
+	// missing monitoring endpoint
+	{map[string]string{"stream": "orders", "consumer": "billing"}, true},
+	// missing stream
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "consumer": "billing"}, true},
+	// missing consumer
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders"}, true},
+	// malformed lagThreshold
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing", "lagThreshold": "fifty"}, true},
+	// leader through the headless service
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing", "useHeadlessServiceForLeader": "true"}, false},
+	// malformed useHeadlessServiceForLeader
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing", "useHeadlessServiceForLeader": "yes please"}, true},
+	// leader endpoint pattern
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing", "leaderMonitoringEndpoint": "%s.nats-headless.nats:8222"}, false},
+	// leader endpoint pattern without the server name
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing", "leaderMonitoringEndpoint": "nats-headless.nats:8222"}, true},
+	// both ways to reach the leader
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "orders", "consumer": "billing", "useHeadlessServiceForLeader": "true", "leaderMonitoringEndpoint": "%s.nats-headless.nats:8222"}, true},
+}
+
+func TestNATSJetStreamParseMetadata(t *testing.T) {
+	for _, testData := range testNATSJetStreamMetadata {
+		_, err := parseNATSJetStreamMetadata(testData.metadata)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+const testJetStreamStandalone = `{"meta_cluster": {}, "account_details": [{"name": "$G", "stream_detail": [
+	{"name": "orders", "consumer_detail": [{"stream_name": "orders", "name": "billing", "num_pending": 8, "num_ack_pending": 2}]}
+]}]}`
+
+// in a cluster the monitoring service answers with outdated values, the consumer leader nats-1 has the current ones
+const testJetStreamClusterService = `{"meta_cluster": {"name": "nats", "leader": "nats-0", "cluster_size": 3}, "account_details": [{"name": "$G", "stream_detail": [
+	{"name": "orders", "cluster": {"name": "nats", "leader": "nats-2"}, "consumer_detail": [{"stream_name": "orders", "name": "billing", "num_pending": 100, "num_ack_pending": 0, "cluster": {"name": "nats", "leader": "nats-1"}}]}
+]}]}`
+
+const testJetStreamClusterLeader = `{"meta_cluster": {"name": "nats", "leader": "nats-0", "cluster_size": 3}, "account_details": [{"name": "$G", "stream_detail": [
+	{"name": "orders", "cluster": {"name": "nats", "leader": "nats-2"}, "consumer_detail": [{"stream_name": "orders", "name": "billing", "num_pending": 3, "num_ack_pending": 1, "cluster": {"name": "nats", "leader": "nats-1"}}]}
+]}]}`
+
+type natsJetStreamMetricsTestData struct {
+	name          string
+	stream        string
+	consumer      string
+	leader        map[string]string
+	responses     map[string]string
+	isError       bool
+	expectedValue int64
+}
+
+var testNATSJetStreamMetrics = []natsJetStreamMetricsTestData{
+	{"standalone", "orders", "billing", nil, map[string]string{"nats.nats:8222": testJetStreamStandalone}, false, 10},
+	{"cluster through the headless service", "orders", "billing", map[string]string{"useHeadlessServiceForLeader": "true"}, map[string]string{"nats.nats:8222": testJetStreamClusterService, "nats-1.nats.nats:8222": testJetStreamClusterLeader}, false, 4},
+	{"cluster through the leader endpoint pattern", "orders", "billing", map[string]string{"leaderMonitoringEndpoint": "%s.nats-headless.nats:8222"}, map[string]string{"nats.nats:8222": testJetStreamClusterService, "nats-1.nats-headless.nats:8222": testJetStreamClusterLeader}, false, 4},
+	{"cluster leader not reachable", "orders", "billing", map[string]string{"useHeadlessServiceForLeader": "true"}, map[string]string{"nats.nats:8222": testJetStreamClusterService}, false, 100},
+	{"cluster without leader endpoint", "orders", "billing", nil, map[string]string{"nats.nats:8222": testJetStreamClusterService, "nats-1.nats.nats:8222": testJetStreamClusterLeader}, false, 100},
+	{"unknown stream", "payments", "billing", nil, map[string]string{"nats.nats:8222": testJetStreamStandalone}, true, 0},
+	{"unknown consumer", "orders", "shipping", nil, map[string]string{"nats.nats:8222": testJetStreamStandalone}, true, 0},
+}
+
+func TestNATSJetStreamGetMetrics(t *testing.T) {
+	for _, testData := range testNATSJetStreamMetrics {
+		responses := testData.responses
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/jsz" || r.URL.Query().Get("acc") != "$G" || r.URL.Query().Get("consumers") != "true" {
+				t.Errorf("Unexpected request %s", r.URL.String())
+			}
+			response, ok := responses[r.Host]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, response)
+		}))
+
+		metadata := map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": testData.stream, "consumer": testData.consumer}
+		for key, value := range testData.leader {
+			metadata[key] = value
+		}
+		scaler, err := NewNATSJetStreamScaler(map[string]string{}, metadata)
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+
+		// route every server of the cluster to the stand-in server, the handler answers depending on the Host header
+		scaler.(*natsJetStreamScaler).httpClient.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+			},
+		}
+
+		metrics, err := scaler.GetMetrics(context.TODO(), "nats-jetstream-orders-billing", nil)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.name)
+		}
+		if err == nil && metrics[0].Value.Value() != testData.expectedValue {
+			t.Errorf("%s: expected value %d but got %d", testData.name, testData.expectedValue, metrics[0].Value.Value())
+		}
+
+		isActive, err := scaler.IsActive(context.TODO())
+		if err == nil && isActive != (testData.expectedValue > 0) {
+			t.Errorf("%s: expected active %t but got %t", testData.name, testData.expectedValue > 0, isActive)
+		}
+
+		server.Close()
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"strconv"
+	"time"
 )
 
 const (
@@ -25,7 +26,7 @@ type postgreSQLScaler struct {
 }
 
 type postgreSQLMetadata struct {
-	targetQueryValue int
+	targetQueryValue float64
 	connection       string
 	userName         string
 	password         string
@@ -34,6 +35,7 @@ type postgreSQLMetadata struct {
 	query            string
 	dbName           string
 	sslmode          string
+	queryTimeout     time.Duration
 }
 
 var postgreSQLLog = logf.Log.WithName("postgreSQL_scaler")
@@ -65,7 +67,7 @@ func parsePostgreSQLMetadata(resolvedEnv, metadata, authParams map[string]string
 	}
 
 	if val, ok := metadata["targetQueryValue"]; ok {
-		targetQueryValue, err := strconv.Atoi(val)
+		targetQueryValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("queryValue parsing error %s", err.Error())
 		}
@@ -74,6 +76,12 @@ func parsePostgreSQLMetadata(resolvedEnv, metadata, authParams map[string]string
 		return nil, fmt.Errorf("no targetQueryValue given")
 	}
 
+	queryTimeout, err := parseSQLQueryTimeout(metadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.queryTimeout = queryTimeout
+
 	if val, ok := authParams["connection"]; ok {
 		meta.connection = val
 	} else if val, ok := metadata["connection"]; ok {
@@ -138,32 +146,23 @@ func getConnection(meta *postgreSQLMetadata) (*sql.DB, error) {
 			meta.password,
 		)
 	}
-	db, err := sql.Open("postgres", connStr)
+	db, err := sqlConnections.get("postgres", connStr)
 	if err != nil {
-		postgreSQLLog.Error(err, fmt.Sprintf("Found error opening postgreSQL: %s", err))
-		return nil, err
-	}
-	err = db.Ping()
-	if err != nil {
-		postgreSQLLog.Error(err, fmt.Sprintf("Found error pinging postgreSQL: %s", err))
+		postgreSQLLog.Error(err, fmt.Sprintf("Found error connecting to postgreSQL: %s", err))
 		return nil, err
 	}
 	return db, nil
 }
 
-// Close disposes of postgres connections
+// Close hands the connection back to the pool, it is shared by the scalers with the same connection settings
 func (s *postgreSQLScaler) Close() error {
-	err := s.connection.Close()
-	if err != nil {
-		postgreSQLLog.Error(err, "Error closing postgreSQL connection")
-		return err
-	}
+	sqlConnections.release(s.connection)
 	return nil
 }
 
 // IsActive returns true if there are pending messages to be processed
 func (s *postgreSQLScaler) IsActive(ctx context.Context) (bool, error) {
-	messages, err := s.getActiveNumber()
+	messages, err := s.getActiveNumber(ctx)
 	if err != nil {
 		return false, fmt.Errorf("error inspecting postgreSQL: %s", err)
 	}
@@ -171,19 +170,19 @@ func (s *postgreSQLScaler) IsActive(ctx context.Context) (bool, error) {
 	return messages > 0, nil
 }
 
-func (s *postgreSQLScaler) getActiveNumber() (int, error) {
-	var id int
-	err := s.connection.QueryRow(s.metadata.query).Scan(&id)
+// getActiveNumber returns the result of the query, NULL and no rows are returned as 0
+func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (float64, error) {
+	value, err := getSQLQueryResult(ctx, s.connection, s.metadata.query, s.metadata.queryTimeout)
 	if err != nil {
 		postgreSQLLog.Error(err, fmt.Sprintf("could not query postgreSQL: %s", err))
 		return 0, fmt.Errorf("could not query postgreSQL: %s", err)
 	}
-	return id, nil
+	return value, nil
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *postgreSQLScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetQueryValue := resource.NewQuantity(int64(s.metadata.targetQueryValue), resource.DecimalSI)
+	targetQueryValue := resource.NewMilliQuantity(int64(s.metadata.targetQueryValue*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{
 		MetricName:         pgMetricName,
 		TargetAverageValue: targetQueryValue,
@@ -196,14 +195,14 @@ func (s *postgreSQLScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *postgreSQLScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	num, err := s.getActiveNumber()
+	num, err := s.getActiveNumber(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting postgreSQL: %s", err)
 	}
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: pgMetricName,
-		Value:      *resource.NewQuantity(int64(num), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(num*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
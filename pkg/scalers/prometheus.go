@@ -8,6 +8,7 @@ import (
 	"net/http"
 	url_pkg "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	v2beta1 "k8s.io/api/autoscaling/v2beta1"
@@ -19,21 +20,47 @@ import (
 )
 
 const (
-	promServerAddress = "serverAddress"
-	promMetricName    = "metricName"
-	promQuery         = "query"
-	promThreshold     = "threshold"
+	promServerAddress  = "serverAddress"
+	promMetricName     = "metricName"
+	promQuery          = "query"
+	promThreshold      = "threshold"
+	promAuthModes      = "authModes"
+	promCustomHeaders  = "customHeaders"
+	promTimeout        = "timeout"
+	promDefaultTimeout = 3000
+)
+
+type prometheusAuthMode string
+
+const (
+	prometheusAuthModeBearer prometheusAuthMode = "bearer"
+	prometheusAuthModeBasic  prometheusAuthMode = "basic"
+	prometheusAuthModeTLS    prometheusAuthMode = "tls"
 )
 
 type prometheusScaler struct {
-	metadata *prometheusMetadata
+	metadata   *prometheusMetadata
+	httpClient *http.Client
 }
 
 type prometheusMetadata struct {
 	serverAddress string
 	metricName    string
 	query         string
-	threshold     int
+	threshold     float64
+	timeout       time.Duration
+	customHeaders map[string]string
+
+	// authentication
+	enableBearerAuth bool
+	enableBasicAuth  bool
+	enableTLS        bool
+	bearerToken      string
+	username         string
+	password         string
+	cert             string
+	key              string
+	ca               string
 }
 
 type promQueryResult struct {
@@ -51,18 +78,24 @@ type promQueryResult struct {
 var prometheusLog = logf.Log.WithName("prometheus_scaler")
 
 // NewPrometheusScaler creates a new prometheusScaler
-func NewPrometheusScaler(resolvedEnv, metadata map[string]string) (Scaler, error) {
-	meta, err := parsePrometheusMetadata(metadata, resolvedEnv)
+func NewPrometheusScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parsePrometheusMetadata(metadata, resolvedEnv, authParams)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing prometheus metadata: %s", err)
 	}
 
+	httpClient, err := getPrometheusHTTPClient(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error creating prometheus http client: %s", err)
+	}
+
 	return &prometheusScaler{
-		metadata: meta,
+		metadata:   meta,
+		httpClient: httpClient,
 	}, nil
 }
 
-func parsePrometheusMetadata(metadata, resolvedEnv map[string]string) (*prometheusMetadata, error) {
+func parsePrometheusMetadata(metadata, resolvedEnv, authParams map[string]string) (*prometheusMetadata, error) {
 	meta := prometheusMetadata{}
 
 	if val, ok := metadata[promServerAddress]; ok && val != "" {
@@ -84,7 +117,7 @@ func parsePrometheusMetadata(metadata, resolvedEnv map[string]string) (*promethe
 	}
 
 	if val, ok := metadata[promThreshold]; ok && val != "" {
-		t, err := strconv.Atoi(val)
+		t, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing %s: %s", promThreshold, err)
 		}
@@ -92,16 +125,124 @@ func parsePrometheusMetadata(metadata, resolvedEnv map[string]string) (*promethe
 		meta.threshold = t
 	}
 
+	meta.timeout = promDefaultTimeout * time.Millisecond
+	if val, ok := metadata[promTimeout]; ok && val != "" {
+		t, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", promTimeout, err)
+		}
+		if t <= 0 {
+			return nil, fmt.Errorf("%s must be greater than 0", promTimeout)
+		}
+
+		meta.timeout = time.Duration(t) * time.Millisecond
+	}
+
+	if val, ok := metadata[promCustomHeaders]; ok && val != "" {
+		headers, err := parsePrometheusCustomHeaders(val)
+		if err != nil {
+			return nil, err
+		}
+
+		meta.customHeaders = headers
+	}
+
+	if val, ok := metadata[promAuthModes]; ok && val != "" {
+		for _, mode := range strings.Split(val, ",") {
+			switch prometheusAuthMode(strings.TrimSpace(mode)) {
+			case prometheusAuthModeBearer:
+				meta.enableBearerAuth = true
+			case prometheusAuthModeBasic:
+				meta.enableBasicAuth = true
+			case prometheusAuthModeTLS:
+				meta.enableTLS = true
+			default:
+				return nil, fmt.Errorf("err incorrect value for %s given: %s", promAuthModes, mode)
+			}
+		}
+	}
+
+	if meta.enableBearerAuth && meta.enableBasicAuth {
+		return nil, fmt.Errorf("both %s and %s auth modes can't be used at the same time", prometheusAuthModeBearer, prometheusAuthModeBasic)
+	}
+
+	if meta.enableBearerAuth {
+		if authParams["bearerToken"] == "" {
+			return nil, fmt.Errorf("no bearerToken given")
+		}
+		meta.bearerToken = authParams["bearerToken"]
+	}
+
+	if meta.enableBasicAuth {
+		if authParams["username"] == "" {
+			return nil, fmt.Errorf("no username given")
+		}
+		meta.username = authParams["username"]
+		// password is optional
+		meta.password = authParams["password"]
+	}
+
+	if meta.enableTLS {
+		if authParams["cert"] == "" {
+			return nil, fmt.Errorf("no cert given")
+		}
+		meta.cert = authParams["cert"]
+
+		if authParams["key"] == "" {
+			return nil, fmt.Errorf("no key given")
+		}
+		meta.key = authParams["key"]
+	}
+
+	// a custom CA can be used with any auth mode
+	meta.ca = authParams["ca"]
+
 	return &meta, nil
 }
 
+// parsePrometheusCustomHeaders parses a comma-separated list of key=value pairs, eg. X-Scope-OrgID=tenant1
+func parsePrometheusCustomHeaders(val string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range strings.Split(val, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+
+		parts := strings.SplitN(header, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("error parsing %s: %s should be in the format of key=value", promCustomHeaders, header)
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers, nil
+}
+
+// getPrometheusHTTPClient returns a client with the configured timeout, client certificate and CA
+func getPrometheusHTTPClient(meta *prometheusMetadata) (*http.Client, error) {
+	client := &http.Client{Timeout: meta.timeout}
+
+	if meta.enableTLS || meta.ca != "" {
+		tlsConfig, err := newTLSConfig(meta.cert, meta.key, meta.ca)
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+
+	return client, nil
+}
+
 func (s *prometheusScaler) IsActive(ctx context.Context) (bool, error) {
-	val, err := s.ExecutePromQuery()
+	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
 		prometheusLog.Error(err, "error executing prometheus query")
 		return false, err
 	}
-	
+
 	return val > -1, nil
 }
 
@@ -113,7 +254,7 @@ func (s *prometheusScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 	return []v2beta1.MetricSpec{
 		{
 			External: &v2beta1.ExternalMetricSource{
-				TargetAverageValue: resource.NewQuantity(int64(s.metadata.threshold), resource.DecimalSI),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.threshold*1000), resource.DecimalSI),
 				MetricName:         s.metadata.metricName,
 			},
 			Type: externalMetricType,
@@ -121,20 +262,39 @@ func (s *prometheusScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 	}
 }
 
-func (s *prometheusScaler) ExecutePromQuery() (float64, error) {
+func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
 	t := time.Now().UTC().Format(time.RFC3339)
 	query_escaped := url_pkg.QueryEscape(s.metadata.query)
 	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.serverAddress, query_escaped, t)
-	r, err := http.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return -1, err
 	}
 
+	for key, value := range s.metadata.customHeaders {
+		req.Header.Set(key, value)
+	}
+
+	if s.metadata.enableBearerAuth {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
+	} else if s.metadata.enableBasicAuth {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	r, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
 	b, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		return -1, err
 	}
-	r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
 
 	var result promQueryResult
 	err = json.Unmarshal(b, &result)
@@ -165,7 +325,7 @@ func (s *prometheusScaler) ExecutePromQuery() (float64, error) {
 }
 
 func (s *prometheusScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	val, err := s.ExecutePromQuery()
+	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
 		prometheusLog.Error(err, "error executing prometheus query")
 		return []external_metrics.ExternalMetricValue{}, err
@@ -173,7 +333,7 @@ func (s *prometheusScaler) GetMetrics(ctx context.Context, metricName string, me
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(val), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(val*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -1,7 +1,12 @@
 package scalers
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 type parsePrometheusMetadataTestData struct {
@@ -9,6 +14,15 @@ type parsePrometheusMetadataTestData struct {
 	isError  bool
 }
 
+type parsePrometheusAuthParamsTestData struct {
+	metadata         map[string]string
+	authParams       map[string]string
+	isError          bool
+	enableBearerAuth bool
+	enableBasicAuth  bool
+	enableTLS        bool
+}
+
 var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{}, true},
 	// all properly formed
@@ -23,11 +37,46 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "", "disableScaleToZero": "true"}, true},
 	// all properly formed, default disableScaleToZero
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}, false},
+	// custom headers and timeout
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "customHeaders": "X-Scope-OrgID=tenant1, X-Other=value", "timeout": "1000"}, false},
+	// malformed custom headers
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "customHeaders": "X-Scope-OrgID"}, true},
+	// malformed timeout
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "timeout": "1s"}, true},
+	// fractional threshold
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "0.75", "query": "up"}, false},
+	// negative timeout
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "timeout": "-1"}, true},
+}
+
+var testPrometheusBaseMetadata = map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}
+
+var testPrometheusAuthParams = []parsePrometheusAuthParamsTestData{
+	// no auth modes
+	{map[string]string{}, map[string]string{}, false, false, false, false},
+	// bearer
+	{map[string]string{"authModes": "bearer"}, map[string]string{"bearerToken": "token"}, false, true, false, false},
+	// bearer without token
+	{map[string]string{"authModes": "bearer"}, map[string]string{}, true, false, false, false},
+	// basic
+	{map[string]string{"authModes": "basic"}, map[string]string{"username": "user", "password": "secret"}, false, false, true, false},
+	// basic without username
+	{map[string]string{"authModes": "basic"}, map[string]string{"password": "secret"}, true, false, false, false},
+	// tls
+	{map[string]string{"authModes": "tls"}, map[string]string{"cert": "cert", "key": "key", "ca": "ca"}, false, false, false, true},
+	// tls without key
+	{map[string]string{"authModes": "tls"}, map[string]string{"cert": "cert"}, true, false, false, false},
+	// tls and basic
+	{map[string]string{"authModes": "tls, basic"}, map[string]string{"cert": "cert", "key": "key", "username": "user"}, false, false, true, true},
+	// bearer and basic
+	{map[string]string{"authModes": "bearer,basic"}, map[string]string{"bearerToken": "token", "username": "user"}, true, false, false, false},
+	// unknown auth mode
+	{map[string]string{"authModes": "digest"}, map[string]string{}, true, false, false, false},
 }
 
 func TestPrometheusParseMetadata(t *testing.T) {
 	for _, testData := range testPromMetadata {
-		_, err := parsePrometheusMetadata(testData.metadata, map[string]string{})
+		_, err := parsePrometheusMetadata(testData.metadata, map[string]string{}, map[string]string{})
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
 		}
@@ -36,3 +85,149 @@ func TestPrometheusParseMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestPrometheusParseAuthParams(t *testing.T) {
+	for _, testData := range testPrometheusAuthParams {
+		metadata := map[string]string{}
+		for key, value := range testPrometheusBaseMetadata {
+			metadata[key] = value
+		}
+		for key, value := range testData.metadata {
+			metadata[key] = value
+		}
+
+		meta, err := parsePrometheusMetadata(metadata, map[string]string{}, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if err != nil {
+			continue
+		}
+		if meta.enableBearerAuth != testData.enableBearerAuth {
+			t.Errorf("Expected enableBearerAuth %t but got %t", testData.enableBearerAuth, meta.enableBearerAuth)
+		}
+		if meta.enableBasicAuth != testData.enableBasicAuth {
+			t.Errorf("Expected enableBasicAuth %t but got %t", testData.enableBasicAuth, meta.enableBasicAuth)
+		}
+		if meta.enableTLS != testData.enableTLS {
+			t.Errorf("Expected enableTLS %t but got %t", testData.enableTLS, meta.enableTLS)
+		}
+	}
+}
+
+func TestPrometheusExecutePromQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Scope-OrgID") != "tenant1" {
+			t.Errorf("Expected X-Scope-OrgID header tenant1 but got %s", r.Header.Get("X-Scope-OrgID"))
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Expected bearer token but got %s", r.Header.Get("Authorization"))
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1585000000, "42.5"]}]}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "bearer", "customHeaders": "X-Scope-OrgID=tenant1"}
+	scaler, err := NewPrometheusScaler(map[string]string{}, metadata, map[string]string{"bearerToken": "token"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	val, err := scaler.(*prometheusScaler).ExecutePromQuery(context.TODO())
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if val != 42.5 {
+		t.Errorf("Expected value 42.5 but got %f", val)
+	}
+}
+
+func TestPrometheusFractionalMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1585000000, "0.25"]}]}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"serverAddress": server.URL, "metricName": "error_ratio", "threshold": "0.75", "query": "up"}
+	scaler, err := NewPrometheusScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	target := scaler.GetMetricSpecForScaling()[0].External.TargetAverageValue
+	if target.String() != "750m" {
+		t.Errorf("Expected target 750m but got %s", target.String())
+	}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "error_ratio", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if metrics[0].Value.String() != "250m" {
+		t.Errorf("Expected value 250m but got %s", metrics[0].Value.String())
+	}
+}
+
+func TestPrometheusExecutePromQueryBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"resultType": "vector", "result": []}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "basic"}
+
+	scaler, err := NewPrometheusScaler(map[string]string{}, metadata, map[string]string{"username": "user", "password": "secret"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.(*prometheusScaler).ExecutePromQuery(context.TODO()); err != nil {
+		t.Error("Expected success but got error", err)
+	}
+
+	scaler, err = NewPrometheusScaler(map[string]string{}, metadata, map[string]string{"username": "user", "password": "wrong"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.(*prometheusScaler).ExecutePromQuery(context.TODO()); err == nil {
+		t.Error("Expected error for wrong credentials but got success")
+	}
+}
+
+func TestPrometheusExecutePromQueryTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	metadata := map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "timeout": "50"}
+	scaler, err := NewPrometheusScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.(*prometheusScaler).ExecutePromQuery(context.TODO()); err == nil {
+		t.Error("Expected timeout error but got success")
+	}
+
+	metadata["timeout"] = "5000"
+	scaler, err = NewPrometheusScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := scaler.(*prometheusScaler).ExecutePromQuery(ctx); err == nil {
+		t.Error("Expected context deadline error but got success")
+	}
+}
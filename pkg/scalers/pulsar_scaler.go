@@ -0,0 +1,333 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	pulsarMsgBacklogThreshold        = "msgBacklogThreshold"
+	defaultPulsarMsgBacklogThreshold = 10
+	pulsarDefaultTimeout             = 3000
+)
+
+type pulsarAuthMode string
+
+const (
+	pulsarAuthModeBearer pulsarAuthMode = "bearer"
+	pulsarAuthModeTLS    pulsarAuthMode = "tls"
+)
+
+type pulsarScaler struct {
+	metadata   *pulsarMetadata
+	httpClient *http.Client
+}
+
+type pulsarMetadata struct {
+	adminURL            string
+	topic               string
+	topicPath           string
+	subscription        string
+	isPartitionedTopic  bool
+	msgBacklogThreshold float64
+	timeout             time.Duration
+
+	// authentication
+	enableBearerAuth bool
+	enableTLS        bool
+	bearerToken      string
+	cert             string
+	key              string
+	ca               string
+}
+
+type pulsarSubscriptionStats struct {
+	MsgBacklog int64 `json:"msgBacklog"`
+}
+
+type pulsarTopicStats struct {
+	Subscriptions map[string]pulsarSubscriptionStats `json:"subscriptions"`
+}
+
+type pulsarPartitionedTopicStats struct {
+	Subscriptions map[string]pulsarSubscriptionStats `json:"subscriptions"`
+	Partitions    map[string]pulsarTopicStats        `json:"partitions"`
+}
+
+var pulsarLog = logf.Log.WithName("pulsar_scaler")
+
+// NewPulsarScaler creates a new pulsar scaler
+func NewPulsarScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parsePulsarMetadata(metadata, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing pulsar metadata: %s", err)
+	}
+
+	httpClient := &http.Client{Timeout: meta.timeout}
+	if meta.enableTLS || meta.ca != "" {
+		tlsConfig, err := newTLSConfig(meta.cert, meta.key, meta.ca)
+		if err != nil {
+			return nil, fmt.Errorf("error creating pulsar tls config: %s", err)
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+
+	return &pulsarScaler{
+		metadata:   meta,
+		httpClient: httpClient,
+	}, nil
+}
+
+func parsePulsarMetadata(metadata, authParams map[string]string) (*pulsarMetadata, error) {
+	meta := pulsarMetadata{}
+
+	if val, ok := metadata["adminURL"]; ok && val != "" {
+		meta.adminURL = strings.TrimRight(val, "/")
+	} else {
+		return nil, fmt.Errorf("no adminURL given")
+	}
+
+	if val, ok := metadata["topic"]; ok && val != "" {
+		topicPath, err := getPulsarTopicPath(val)
+		if err != nil {
+			return nil, err
+		}
+		meta.topic = val
+		meta.topicPath = topicPath
+	} else {
+		return nil, fmt.Errorf("no topic given")
+	}
+
+	if val, ok := metadata["subscription"]; ok && val != "" {
+		meta.subscription = val
+	} else {
+		return nil, fmt.Errorf("no subscription given")
+	}
+
+	if val, ok := metadata["isPartitionedTopic"]; ok && val != "" {
+		isPartitionedTopic, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing isPartitionedTopic: %s", err)
+		}
+		meta.isPartitionedTopic = isPartitionedTopic
+	}
+
+	meta.msgBacklogThreshold = defaultPulsarMsgBacklogThreshold
+	if val, ok := metadata[pulsarMsgBacklogThreshold]; ok && val != "" {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", pulsarMsgBacklogThreshold, err)
+		}
+		meta.msgBacklogThreshold = t
+	}
+
+	meta.timeout = pulsarDefaultTimeout * time.Millisecond
+	if val, ok := metadata["timeout"]; ok && val != "" {
+		t, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing timeout: %s", err)
+		}
+		if t <= 0 {
+			return nil, fmt.Errorf("timeout must be greater than 0")
+		}
+		meta.timeout = time.Duration(t) * time.Millisecond
+	}
+
+	if val, ok := metadata["authModes"]; ok && val != "" {
+		for _, mode := range strings.Split(val, ",") {
+			switch pulsarAuthMode(strings.TrimSpace(mode)) {
+			case pulsarAuthModeBearer:
+				meta.enableBearerAuth = true
+			case pulsarAuthModeTLS:
+				meta.enableTLS = true
+			default:
+				return nil, fmt.Errorf("err incorrect value for authModes given: %s", mode)
+			}
+		}
+	}
+
+	if meta.enableBearerAuth {
+		if authParams["bearerToken"] == "" {
+			return nil, fmt.Errorf("no bearerToken given")
+		}
+		meta.bearerToken = authParams["bearerToken"]
+	}
+
+	if meta.enableTLS {
+		if authParams["cert"] == "" {
+			return nil, fmt.Errorf("no cert given")
+		}
+		meta.cert = authParams["cert"]
+
+		if authParams["key"] == "" {
+			return nil, fmt.Errorf("no key given")
+		}
+		meta.key = authParams["key"]
+	}
+
+	// a custom CA can be used with any auth mode
+	meta.ca = authParams["ca"]
+
+	return &meta, nil
+}
+
+// getPulsarTopicPath returns the admin api path of a topic given as persistent://tenant/namespace/topic
+// or tenant/namespace/topic, topics are persistent by default
+func getPulsarTopicPath(topic string) (string, error) {
+	domain := "persistent"
+	name := topic
+	if parts := strings.SplitN(topic, "://", 2); len(parts) == 2 {
+		domain = parts[0]
+		name = parts[1]
+	}
+
+	if domain != "persistent" && domain != "non-persistent" {
+		return "", fmt.Errorf("invalid topic %s, the topic domain must be persistent or non-persistent", topic)
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", fmt.Errorf("invalid topic %s, the topic must be in the format of persistent://tenant/namespace/topic", topic)
+	}
+
+	return fmt.Sprintf("%s/%s", domain, name), nil
+}
+
+func (s *pulsarScaler) getStatsEndpoint() string {
+	if s.metadata.isPartitionedTopic {
+		return fmt.Sprintf("%s/admin/v2/%s/partitioned-stats?perPartition=true", s.metadata.adminURL, s.metadata.topicPath)
+	}
+	return fmt.Sprintf("%s/admin/v2/%s/stats", s.metadata.adminURL, s.metadata.topicPath)
+}
+
+func (s *pulsarScaler) getStats(ctx context.Context, stats interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, s.getStatsEndpoint(), nil)
+	if err != nil {
+		return err
+	}
+
+	if s.metadata.enableBearerAuth {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
+	}
+
+	r, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	if r.StatusCode != http.StatusOK {
+		return fmt.Errorf("pulsar admin api returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	return json.Unmarshal(b, stats)
+}
+
+// getMsgBacklog returns the backlog of the subscription, summed across the partitions of a partitioned topic
+func (s *pulsarScaler) getMsgBacklog(ctx context.Context) (int64, error) {
+	if !s.metadata.isPartitionedTopic {
+		stats := pulsarTopicStats{}
+		if err := s.getStats(ctx, &stats); err != nil {
+			return 0, err
+		}
+
+		subscription, ok := stats.Subscriptions[s.metadata.subscription]
+		if !ok {
+			return 0, fmt.Errorf("subscription %s not found in topic %s", s.metadata.subscription, s.metadata.topic)
+		}
+		return subscription.MsgBacklog, nil
+	}
+
+	stats := pulsarPartitionedTopicStats{}
+	if err := s.getStats(ctx, &stats); err != nil {
+		return 0, err
+	}
+
+	// older brokers don't report the partitions, the subscriptions are aggregated across partitions then
+	if len(stats.Partitions) == 0 {
+		subscription, ok := stats.Subscriptions[s.metadata.subscription]
+		if !ok {
+			return 0, fmt.Errorf("subscription %s not found in topic %s", s.metadata.subscription, s.metadata.topic)
+		}
+		return subscription.MsgBacklog, nil
+	}
+
+	found := false
+	msgBacklog := int64(0)
+	for _, partition := range stats.Partitions {
+		if subscription, ok := partition.Subscriptions[s.metadata.subscription]; ok {
+			found = true
+			msgBacklog += subscription.MsgBacklog
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("subscription %s not found in topic %s", s.metadata.subscription, s.metadata.topic)
+	}
+	return msgBacklog, nil
+}
+
+// IsActive determines if the subscription has a backlog
+func (s *pulsarScaler) IsActive(ctx context.Context) (bool, error) {
+	msgBacklog, err := s.getMsgBacklog(ctx)
+	if err != nil {
+		pulsarLog.Error(err, "error requesting pulsar topic stats", "topic", s.metadata.topic)
+		return false, err
+	}
+
+	return msgBacklog > 0, nil
+}
+
+func (s *pulsarScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	return []v2beta1.MetricSpec{
+		{
+			External: &v2beta1.ExternalMetricSource{
+				MetricName:         fmt.Sprintf("%s-%s-%s", "pulsar", sanitizeMetricName(s.metadata.topicPath), sanitizeMetricName(s.metadata.subscription)),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.msgBacklogThreshold*1000), resource.DecimalSI),
+			},
+			Type: externalMetricType,
+		},
+	}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *pulsarScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	msgBacklog, err := s.getMsgBacklog(ctx)
+	if err != nil {
+		pulsarLog.Error(err, "error requesting pulsar topic stats", "topic", s.metadata.topic)
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(msgBacklog*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// Close does nothing for the pulsar scaler
+func (s *pulsarScaler) Close() error {
+	return nil
+}
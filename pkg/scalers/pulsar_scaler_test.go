@@ -0,0 +1,199 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type parsePulsarMetadataTestData struct {
+	metadata          map[string]string
+	authParams        map[string]string
+	isError           bool
+	expectedTopicPath string
+}
+
+var testPulsarMetadata = []parsePulsarMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true, ""},
+	// properly formed
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "persistent://public/default/orders", "subscription": "billing"}, map[string]string{}, false, "persistent/public/default/orders"},
+	// topic without domain, partitioned, threshold
+	{map[string]string{"adminURL": "http://pulsar:8080/", "topic": "public/default/orders", "subscription": "billing", "isPartitionedTopic": "true", "msgBacklogThreshold": "50"}, map[string]string{}, false, "persistent/public/default/orders"},
+	// non-persistent topic
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "non-persistent://public/default/orders", "subscription": "billing"}, map[string]string{}, false, "non-persistent/public/default/orders"},
+	// unknown topic domain
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "kafka://public/default/orders", "subscription": "billing"}, map[string]string{}, true, ""},
+	// topic without namespace
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "persistent://public/orders", "subscription": "billing"}, map[string]string{}, true, ""},
+	// missing adminURL
+	{map[string]string{"topic": "persistent://public/default/orders", "subscription": "billing"}, map[string]string{}, true, ""},
+	// missing topic
+	{map[string]string{"adminURL": "http://pulsar:8080", "subscription": "billing"}, map[string]string{}, true, ""},
+	// missing subscription
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "persistent://public/default/orders"}, map[string]string{}, true, ""},
+	// malformed isPartitionedTopic
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "persistent://public/default/orders", "subscription": "billing", "isPartitionedTopic": "yes please"}, map[string]string{}, true, ""},
+	// malformed msgBacklogThreshold
+	{map[string]string{"adminURL": "http://pulsar:8080", "topic": "persistent://public/default/orders", "subscription": "billing", "msgBacklogThreshold": "ten"}, map[string]string{}, true, ""},
+	// token auth
+	{map[string]string{"adminURL": "https://pulsar:8443", "topic": "persistent://public/default/orders", "subscription": "billing", "authModes": "bearer"}, map[string]string{"bearerToken": "token"}, false, "persistent/public/default/orders"},
+	// token auth without token
+	{map[string]string{"adminURL": "https://pulsar:8443", "topic": "persistent://public/default/orders", "subscription": "billing", "authModes": "bearer"}, map[string]string{}, true, ""},
+	// tls auth
+	{map[string]string{"adminURL": "https://pulsar:8443", "topic": "persistent://public/default/orders", "subscription": "billing", "authModes": "tls"}, map[string]string{"cert": "cert", "key": "key"}, false, "persistent/public/default/orders"},
+	// tls auth without cert
+	{map[string]string{"adminURL": "https://pulsar:8443", "topic": "persistent://public/default/orders", "subscription": "billing", "authModes": "tls"}, map[string]string{"key": "key"}, true, ""},
+	// unknown auth mode
+	{map[string]string{"adminURL": "https://pulsar:8443", "topic": "persistent://public/default/orders", "subscription": "billing", "authModes": "oauth"}, map[string]string{}, true, ""},
+}
+
+func TestParsePulsarMetadata(t *testing.T) {
+	for _, testData := range testPulsarMetadata {
+		meta, err := parsePulsarMetadata(testData.metadata, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if err == nil && meta.topicPath != testData.expectedTopicPath {
+			t.Errorf("Expected topic path %s but got %s", testData.expectedTopicPath, meta.topicPath)
+		}
+	}
+}
+
+type pulsarMetricsTestData struct {
+	name          string
+	metadata      map[string]string
+	path          string
+	response      string
+	isError       bool
+	expectedValue int64
+}
+
+var testPulsarMetrics = []pulsarMetricsTestData{
+	{
+		"topic",
+		map[string]string{"subscription": "billing"},
+		"/admin/v2/persistent/public/default/orders/stats",
+		`{"msgInCounter": 100, "subscriptions": {"billing": {"msgBacklog": 12}, "audit": {"msgBacklog": 40}}}`,
+		false, 12,
+	},
+	{
+		"partitioned topic",
+		map[string]string{"subscription": "billing", "isPartitionedTopic": "true"},
+		"/admin/v2/persistent/public/default/orders/partitioned-stats",
+		`{"subscriptions": {"billing": {"msgBacklog": 99}}, "partitions": {
+			"persistent://public/default/orders-partition-0": {"subscriptions": {"billing": {"msgBacklog": 3}}},
+			"persistent://public/default/orders-partition-1": {"subscriptions": {"billing": {"msgBacklog": 4}, "audit": {"msgBacklog": 20}}}}}`,
+		false, 7,
+	},
+	{
+		"partitioned topic without partition stats",
+		map[string]string{"subscription": "billing", "isPartitionedTopic": "true"},
+		"/admin/v2/persistent/public/default/orders/partitioned-stats",
+		`{"subscriptions": {"billing": {"msgBacklog": 9}}}`,
+		false, 9,
+	},
+	{
+		"unknown subscription",
+		map[string]string{"subscription": "shipping"},
+		"/admin/v2/persistent/public/default/orders/stats",
+		`{"subscriptions": {"billing": {"msgBacklog": 12}}}`,
+		true, 0,
+	},
+	{
+		"unknown subscription in partitioned topic",
+		map[string]string{"subscription": "shipping", "isPartitionedTopic": "true"},
+		"/admin/v2/persistent/public/default/orders/partitioned-stats",
+		`{"partitions": {"persistent://public/default/orders-partition-0": {"subscriptions": {"billing": {"msgBacklog": 3}}}}}`,
+		true, 0,
+	},
+}
+
+func TestPulsarGetMetrics(t *testing.T) {
+	for _, testData := range testPulsarMetrics {
+		path, response := testData.path, testData.response
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != path {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, response)
+		}))
+
+		metadata := map[string]string{"adminURL": server.URL, "topic": "persistent://public/default/orders", "authModes": "bearer"}
+		for key, value := range testData.metadata {
+			metadata[key] = value
+		}
+		scaler, err := NewPulsarScaler(map[string]string{}, metadata, map[string]string{"bearerToken": "token"})
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+
+		metrics, err := scaler.GetMetrics(context.TODO(), "pulsar", nil)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.name)
+		}
+		if err == nil && metrics[0].Value.Value() != testData.expectedValue {
+			t.Errorf("%s: expected value %d but got %d", testData.name, testData.expectedValue, metrics[0].Value.Value())
+		}
+
+		server.Close()
+	}
+}
+
+func TestPulsarTokenAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"subscriptions": {"billing": {"msgBacklog": 0}}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"adminURL": server.URL, "topic": "persistent://public/default/orders", "subscription": "billing"}
+	scaler, err := NewPulsarScaler(map[string]string{}, metadata, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error without token but got success")
+	}
+
+	metadata["authModes"] = "bearer"
+	scaler, err = NewPulsarScaler(map[string]string{}, metadata, map[string]string{"bearerToken": "token"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if isActive, err := scaler.IsActive(context.TODO()); err != nil || isActive {
+		t.Errorf("Expected inactive without error but got %t, %v", isActive, err)
+	}
+
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "pulsar-persistent-public-default-orders-billing" {
+		t.Errorf("Expected metric pulsar-persistent-public-default-orders-billing but got %s", name)
+	}
+}
+
+func TestPulsarMetricName(t *testing.T) {
+	meta, err := parsePulsarMetadata(map[string]string{"adminURL": "http://pulsar:8080", "topic": "persistent://public/default/orders.eu", "subscription": "billing_v2"}, map[string]string{})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	scaler := pulsarScaler{metadata: meta}
+	if name := scaler.GetMetricSpecForScaling()[0].External.MetricName; name != "pulsar-persistent-public-default-orders-eu-billing-v2" {
+		t.Errorf("Expected metric pulsar-persistent-public-default-orders-eu-billing-v2 but got %s", name)
+	}
+}
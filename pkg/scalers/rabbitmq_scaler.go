@@ -2,8 +2,14 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/streadway/amqp"
 	v2beta1 "k8s.io/api/autoscaling/v2beta1"
@@ -16,19 +22,57 @@ import (
 
 const (
 	rabbitQueueLengthMetricName = "queueLength"
+	rabbitMessageRateMetricName = "messageRate"
 	rabbitMetricType            = "External"
+	rabbitDefaultVhost          = "/"
+	rabbitHTTPTimeout           = 10 * time.Second
+)
+
+type rabbitMQProtocol string
+
+const (
+	rabbitProtocolAMQP rabbitMQProtocol = "amqp"
+	rabbitProtocolHTTP rabbitMQProtocol = "http"
+)
+
+type rabbitMQMode string
+
+const (
+	rabbitModeQueueLength rabbitMQMode = "QueueLength"
+	rabbitModeMessageRate rabbitMQMode = "MessageRate"
 )
 
 type rabbitMQScaler struct {
 	metadata   *rabbitMQMetadata
 	connection *amqp.Connection
 	channel    *amqp.Channel
+	httpClient *http.Client
 }
 
 type rabbitMQMetadata struct {
 	queueName   string
+	queueRegex  *regexp.Regexp
 	host        string
-	queueLength int
+	protocol    rabbitMQProtocol
+	vhostName   string
+	mode        rabbitMQMode
+	queueLength float64
+	messageRate float64
+}
+
+// rabbitMQQueueInfo is the subset of a queue returned by the management API
+type rabbitMQQueueInfo struct {
+	Name         string               `json:"name"`
+	Messages     int64                `json:"messages"`
+	MessageStats rabbitMQMessageStats `json:"message_stats"`
+}
+
+type rabbitMQMessageStats struct {
+	PublishDetails rabbitMQRateDetails `json:"publish_details"`
+}
+
+type rabbitMQRateDetails struct {
+	Rate float64 `json:"rate"`
 }
 
 var rabbitmqLog = logf.Log.WithName("rabbitmq_scaler")
@@ -40,6 +84,13 @@ func NewRabbitMQScaler(resolvedEnv, metadata, authParams map[string]string) (Sca
 		return nil, fmt.Errorf("error parsing rabbitmq metadata: %s", err)
 	}
 
+	if meta.protocol == rabbitProtocolHTTP {
+		return &rabbitMQScaler{
+			metadata:   meta,
+			httpClient: &http.Client{Timeout: rabbitHTTPTimeout},
+		}, nil
+	}
+
 	conn, ch, err := getConnectionAndChannel(meta.host)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing rabbitmq connection: %s", err)
@@ -69,21 +120,83 @@ func parseRabbitMQMetadata(resolvedEnv, metadata, authParams map[string]string)
 		return nil, fmt.Errorf("no host setting given")
 	}
 
+	meta.protocol = rabbitProtocolAMQP
+	if val, ok := metadata["protocol"]; ok && val != "" {
+		switch rabbitMQProtocol(val) {
+		case rabbitProtocolAMQP, rabbitProtocolHTTP:
+			meta.protocol = rabbitMQProtocol(val)
+		default:
+			return nil, fmt.Errorf("protocol %s is not supported, must be either %s or %s", val, rabbitProtocolAMQP, rabbitProtocolHTTP)
+		}
+	}
+
 	if val, ok := metadata["queueName"]; ok {
 		meta.queueName = val
 	} else {
 		return nil, fmt.Errorf("no queue name given")
 	}
 
-	if val, ok := metadata[rabbitQueueLengthMetricName]; ok {
-		queueLength, err := strconv.Atoi(val)
+	if val, ok := metadata["useRegex"]; ok {
+		useRegex, err := strconv.ParseBool(val)
 		if err != nil {
-			return nil, fmt.Errorf("can't parse %s: %s", rabbitQueueLengthMetricName, err)
+			return nil, fmt.Errorf("can't parse useRegex: %s", err)
+		}
+		if useRegex {
+			if meta.protocol != rabbitProtocolHTTP {
+				return nil, fmt.Errorf("useRegex is only supported with protocol %s", rabbitProtocolHTTP)
+			}
+			queueRegex, err := regexp.Compile(meta.queueName)
+			if err != nil {
+				return nil, fmt.Errorf("can't compile queueName as regex: %s", err)
+			}
+			meta.queueRegex = queueRegex
 		}
+	}
 
-		meta.queueLength = queueLength
-	} else {
-		return nil, fmt.Errorf("no queue length given")
+	meta.vhostName = rabbitDefaultVhost
+	if val, ok := metadata["vhostName"]; ok && val != "" {
+		if meta.protocol != rabbitProtocolHTTP {
+			return nil, fmt.Errorf("vhostName is only supported with protocol %s, set the vhost in the host URL instead", rabbitProtocolHTTP)
+		}
+		meta.vhostName = val
+	}
+
+	meta.mode = rabbitModeQueueLength
+	if val, ok := metadata["mode"]; ok && val != "" {
+		switch rabbitMQMode(val) {
+		case rabbitModeQueueLength, rabbitModeMessageRate:
+			meta.mode = rabbitMQMode(val)
+		default:
+			return nil, fmt.Errorf("mode %s is not supported, must be either %s or %s", val, rabbitModeQueueLength, rabbitModeMessageRate)
+		}
+	}
+
+	switch meta.mode {
+	case rabbitModeQueueLength:
+		if val, ok := metadata[rabbitQueueLengthMetricName]; ok {
+			queueLength, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse %s: %s", rabbitQueueLengthMetricName, err)
+			}
+
+			meta.queueLength = queueLength
+		} else {
+			return nil, fmt.Errorf("no queue length given")
+		}
+	case rabbitModeMessageRate:
+		if meta.protocol != rabbitProtocolHTTP {
+			return nil, fmt.Errorf("mode %s is only supported with protocol %s", rabbitModeMessageRate, rabbitProtocolHTTP)
+		}
+		if val, ok := metadata[rabbitMessageRateMetricName]; ok {
+			messageRate, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse %s: %s", rabbitMessageRateMetricName, err)
+			}
+
+			meta.messageRate = messageRate
+		} else {
+			return nil, fmt.Errorf("no message rate given")
+		}
 	}
 
 	return &meta, nil
@@ -105,6 +218,10 @@ func getConnectionAndChannel(host string) (*amqp.Connection, *amqp.Channel, erro
 
 // Close disposes of RabbitMQ connections
 func (s *rabbitMQScaler) Close() error {
+	if s.connection == nil {
+		return nil
+	}
+
 	err := s.connection.Close()
 	if err != nil {
 		rabbitmqLog.Error(err, "Error closing rabbitmq connection")
@@ -113,32 +230,103 @@ func (s *rabbitMQScaler) Close() error {
 	return nil
 }
 
-// IsActive returns true if there are pending messages to be processed
+// IsActive returns true if there are pending messages to be processed, or messages being published in MessageRate mode
 func (s *rabbitMQScaler) IsActive(ctx context.Context) (bool, error) {
-	messages, err := s.getQueueMessages()
+	messages, publishRate, err := s.getQueueStatus(ctx)
 	if err != nil {
 		return false, fmt.Errorf("error inspecting rabbitMQ: %s", err)
 	}
 
+	if s.metadata.mode == rabbitModeMessageRate {
+		return publishRate > 0, nil
+	}
 	return messages > 0, nil
 }
 
-func (s *rabbitMQScaler) getQueueMessages() (int, error) {
+// getQueueStatus returns the number of messages and the publish rate of the queue,
+// or their sum across all queues matching the regex
+func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, error) {
+	if s.metadata.protocol == rabbitProtocolHTTP {
+		return s.getQueueStatusFromManagementAPI(ctx)
+	}
+
 	items, err := s.channel.QueueInspect(s.metadata.queueName)
 	if err != nil {
-		return -1, err
+		return -1, -1, err
 	}
 
-	return items.Messages, nil
+	return int64(items.Messages), 0, nil
+}
+
+func (s *rabbitMQScaler) getQueueStatusFromManagementAPI(ctx context.Context) (int64, float64, error) {
+	baseURL := strings.TrimSuffix(s.metadata.host, "/")
+	vhost := url.PathEscape(s.metadata.vhostName)
+
+	if s.metadata.queueRegex == nil {
+		var queue rabbitMQQueueInfo
+		endpoint := fmt.Sprintf("%s/api/queues/%s/%s", baseURL, vhost, url.PathEscape(s.metadata.queueName))
+		if err := s.getJSON(ctx, endpoint, &queue); err != nil {
+			return -1, -1, err
+		}
+		return queue.Messages, queue.MessageStats.PublishDetails.Rate, nil
+	}
+
+	var queues []rabbitMQQueueInfo
+	endpoint := fmt.Sprintf("%s/api/queues/%s", baseURL, vhost)
+	if err := s.getJSON(ctx, endpoint, &queues); err != nil {
+		return -1, -1, err
+	}
+
+	var messages int64
+	var publishRate float64
+	for _, queue := range queues {
+		if s.metadata.queueRegex.MatchString(queue.Name) {
+			messages += queue.Messages
+			publishRate += queue.MessageStats.PublishDetails.Rate
+		}
+	}
+	return messages, publishRate, nil
+}
+
+// getJSON queries the management API, the credentials are taken from the host URL
+func (s *rabbitMQScaler) getJSON(ctx context.Context, endpoint string, target interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("management API returned status %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *rabbitMQScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	if s.metadata.mode == rabbitModeMessageRate {
+		return []v2beta1.MetricSpec{
+			{
+				External: &v2beta1.ExternalMetricSource{
+					MetricName:         rabbitMessageRateMetricName,
+					TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.messageRate*1000), resource.DecimalSI),
+				},
+				Type: rabbitMetricType,
+			},
+		}
+	}
+
 	return []v2beta1.MetricSpec{
 		{
 			External: &v2beta1.ExternalMetricSource{
 				MetricName:         rabbitQueueLengthMetricName,
-				TargetAverageValue: resource.NewQuantity(int64(s.metadata.queueLength), resource.DecimalSI),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.queueLength*1000), resource.DecimalSI),
 			},
 			Type: rabbitMetricType,
 		},
@@ -147,14 +335,23 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	messages, err := s.getQueueMessages()
+	messages, publishRate, err := s.getQueueStatus(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting rabbitMQ: %s", err)
 	}
 
+	if s.metadata.mode == rabbitModeMessageRate {
+		metric := external_metrics.ExternalMetricValue{
+			MetricName: rabbitMessageRateMetricName,
+			Value:      *resource.NewMilliQuantity(int64(publishRate*1000), resource.DecimalSI),
+			Timestamp:  metav1.Now(),
+		}
+		return append([]external_metrics.ExternalMetricValue{}, metric), nil
+	}
+
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: rabbitQueueLengthMetricName,
-		Value:      *resource.NewQuantity(int64(messages), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(messages*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -1,6 +1,10 @@
 package scalers
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -31,6 +35,112 @@ var testRabbitMQMetadata = []parseRabbitMQMetadataTestData{
 	{map[string]string{"queueLength": "10", "host": host}, true, map[string]string{}},
 	// host defined in authParams
 	{map[string]string{"queueLength": "10"}, true, map[string]string{"host": host}},
+	// properly formed metadata with http protocol
+	{map[string]string{"queueLength": "10", "queueName": "sample", "host": host, "protocol": "http", "vhostName": "myvhost"}, false, map[string]string{}},
+	// invalid protocol
+	{map[string]string{"queueLength": "10", "queueName": "sample", "host": host, "protocol": "mqtt"}, true, map[string]string{}},
+	// vhostName requires http protocol
+	{map[string]string{"queueLength": "10", "queueName": "sample", "host": host, "vhostName": "myvhost"}, true, map[string]string{}},
+	// properly formed MessageRate mode
+	{map[string]string{"messageRate": "100", "queueName": "sample", "host": host, "protocol": "http", "mode": "MessageRate"}, false, map[string]string{}},
+	// MessageRate mode requires http protocol
+	{map[string]string{"messageRate": "100", "queueName": "sample", "host": host, "mode": "MessageRate"}, true, map[string]string{}},
+	// MessageRate mode without messageRate
+	{map[string]string{"queueLength": "10", "queueName": "sample", "host": host, "protocol": "http", "mode": "MessageRate"}, true, map[string]string{}},
+	// invalid mode
+	{map[string]string{"queueLength": "10", "queueName": "sample", "host": host, "protocol": "http", "mode": "Unacked"}, true, map[string]string{}},
+	// properly formed regex
+	{map[string]string{"queueLength": "10", "queueName": "^sample-.*$", "host": host, "protocol": "http", "useRegex": "true"}, false, map[string]string{}},
+	// invalid regex
+	{map[string]string{"queueLength": "10", "queueName": "sample-(", "host": host, "protocol": "http", "useRegex": "true"}, true, map[string]string{}},
+	// useRegex requires http protocol
+	{map[string]string{"queueLength": "10", "queueName": "^sample-.*$", "host": host, "useRegex": "true"}, true, map[string]string{}},
+}
+
+type rabbitMQManagementAPITestData struct {
+	comment         string
+	metadata        map[string]string
+	expectedValue   string
+	expectedActive  bool
+	expectedRequest string
+}
+
+var testRabbitMQManagementAPI = []rabbitMQManagementAPITestData{
+	{"queue length", map[string]string{"queueLength": "10", "queueName": "sample"}, "4", true, "/api/queues/%2F/sample"},
+	{"message rate", map[string]string{"messageRate": "10", "queueName": "sample", "mode": "MessageRate"}, "1500m", true, "/api/queues/%2F/sample"},
+	{"vhost", map[string]string{"queueLength": "10", "queueName": "sample", "vhostName": "my vhost"}, "4", true, "/api/queues/my%20vhost/sample"},
+	{"regex queue length", map[string]string{"queueLength": "10", "queueName": "^sample-", "useRegex": "true"}, "6", true, "/api/queues/%2F"},
+	{"regex message rate", map[string]string{"messageRate": "10", "queueName": "^sample-", "useRegex": "true", "mode": "MessageRate"}, "2500m", true, "/api/queues/%2F"},
+	{"regex without match", map[string]string{"queueLength": "10", "queueName": "^other-", "useRegex": "true"}, "0", false, "/api/queues/%2F"},
+}
+
+func TestRabbitMQManagementAPI(t *testing.T) {
+	for _, testData := range testRabbitMQManagementAPI {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != testData.expectedRequest {
+				t.Errorf("%s: expected request to %s but got %s", testData.comment, testData.expectedRequest, r.URL.EscapedPath())
+			}
+			if user, password, ok := r.BasicAuth(); !ok || user != "guest" || password != "secret" {
+				t.Errorf("%s: expected basic auth credentials from the host URL", testData.comment)
+			}
+
+			if r.URL.EscapedPath() == testData.expectedRequest && testData.metadata["useRegex"] == "true" {
+				fmt.Fprint(w, `[
+					{"name": "sample-1", "messages": 2, "message_stats": {"publish_details": {"rate": 1.5}}},
+					{"name": "sample-2", "messages": 4, "message_stats": {"publish_details": {"rate": 1}}},
+					{"name": "unrelated", "messages": 8}
+				]`)
+				return
+			}
+			fmt.Fprint(w, `{"name": "sample", "messages": 4, "message_stats": {"publish_details": {"rate": 1.5}}}`)
+		}))
+
+		metadata := map[string]string{"protocol": "http"}
+		for key, value := range testData.metadata {
+			metadata[key] = value
+		}
+		authParams := map[string]string{"host": "http://guest:secret@" + server.Listener.Addr().String()}
+
+		scaler, err := NewRabbitMQScaler(map[string]string{}, metadata, authParams)
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+			server.Close()
+			continue
+		}
+
+		metrics, err := scaler.GetMetrics(context.TODO(), "queueLength", nil)
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+		} else if metrics[0].Value.String() != testData.expectedValue {
+			t.Errorf("%s: expected value %s but got %s", testData.comment, testData.expectedValue, metrics[0].Value.String())
+		}
+
+		isActive, err := scaler.IsActive(context.TODO())
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+		} else if isActive != testData.expectedActive {
+			t.Errorf("%s: expected active %t but got %t", testData.comment, testData.expectedActive, isActive)
+		}
+
+		server.Close()
+	}
+}
+
+func TestRabbitMQManagementAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"protocol": "http", "queueLength": "10", "queueName": "missing"}
+	scaler, err := NewRabbitMQScaler(map[string]string{}, metadata, map[string]string{"host": server.URL})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	if _, err := scaler.IsActive(context.TODO()); err == nil {
+		t.Error("Expected error but got success")
+	}
 }
 
 func TestRabbitMQParseMetadata(t *testing.T) {
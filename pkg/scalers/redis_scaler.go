@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-redis/redis"
 	v2beta1 "k8s.io/api/autoscaling/v2beta1"
@@ -29,12 +30,29 @@ type redisScaler struct {
 }
 
 type redisMetadata struct {
-	targetListLength int
+	targetListLength float64
 	listName         string
-	address          string
-	password         string
-	databaseIndex    int
-	enableTLS        bool
+	connectionInfo   redisConnectionInfo
+}
+
+type redisConnectionType string
+
+const (
+	redisConnectionTypeSingle   redisConnectionType = "single"
+	redisConnectionTypeCluster  redisConnectionType = "cluster"
+	redisConnectionTypeSentinel redisConnectionType = "sentinel"
+)
+
+// redisConnectionInfo holds the settings shared by the Redis scalers to connect to Redis.
+// A single node is reached through address, a Cluster or Sentinel through addresses.
+type redisConnectionInfo struct {
+	connectionType redisConnectionType
+	address        string
+	addresses      []string
+	sentinelMaster string
+	password       string
+	databaseIndex  int
+	enableTLS      bool
 }
 
 var redisLog = logf.Log.WithName("redis_scaler")
@@ -56,7 +74,7 @@ func parseRedisMetadata(metadata, resolvedEnv, authParams map[string]string) (*r
 	meta.targetListLength = defaultTargetListLength
 
 	if val, ok := metadata["listLength"]; ok {
-		listLength, err := strconv.Atoi(val)
+		listLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("List length parsing error %s", err.Error())
 		}
@@ -69,52 +87,105 @@ func parseRedisMetadata(metadata, resolvedEnv, authParams map[string]string) (*r
 		return nil, fmt.Errorf("no list name given")
 	}
 
-	address := defaultRedisAddress
-	if val, ok := metadata["address"]; ok && val != "" {
-		address = val
+	connectionInfo, err := parseRedisConnectionInfo(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, err
+	}
+	meta.connectionInfo = connectionInfo
+
+	return &meta, nil
+}
+
+// parseRedisConnectionInfo parses the address, password, database and TLS settings of a Redis trigger.
+// When addresses is given the trigger connects to a Redis Cluster, or to the Sentinels of sentinelMaster if it is set.
+func parseRedisConnectionInfo(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
+	info := redisConnectionInfo{}
+
+	addresses := ""
+	if val, ok := authParams["addresses"]; ok {
+		addresses = val
+	} else if val, ok := metadata["addresses"]; ok && val != "" {
+		if resolved, ok := resolvedEnv[val]; ok {
+			addresses = resolved
+		} else {
+			return info, fmt.Errorf("addresses %s could not be resolved", val)
+		}
 	}
 
-	if val, ok := resolvedEnv[address]; ok {
-		meta.address = val
+	if addresses != "" {
+		for _, address := range strings.Split(addresses, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				info.addresses = append(info.addresses, address)
+			}
+		}
+		if len(info.addresses) == 0 {
+			return info, fmt.Errorf("no addresses given. Addresses should be a comma-separated list of host:port")
+		}
+
+		info.connectionType = redisConnectionTypeCluster
+		if val, ok := authParams["sentinelMaster"]; ok {
+			info.sentinelMaster = val
+		} else if val, ok := metadata["sentinelMaster"]; ok {
+			info.sentinelMaster = val
+		}
+		if info.sentinelMaster != "" {
+			info.connectionType = redisConnectionTypeSentinel
+		}
 	} else {
-		return nil, fmt.Errorf("no address given. Address should be in the format of host:port")
+		if _, ok := metadata["sentinelMaster"]; ok {
+			return info, fmt.Errorf("sentinelMaster requires the addresses of the Sentinels")
+		}
+
+		info.connectionType = redisConnectionTypeSingle
+		address := defaultRedisAddress
+		if val, ok := metadata["address"]; ok && val != "" {
+			address = val
+		}
+
+		if val, ok := resolvedEnv[address]; ok {
+			info.address = val
+		} else {
+			return info, fmt.Errorf("no address given. Address should be in the format of host:port")
+		}
 	}
 
-	meta.password = defaultRedisPassword
+	info.password = defaultRedisPassword
 	if val, ok := authParams["password"]; ok {
-		meta.password = val
+		info.password = val
 	} else if val, ok := metadata["password"]; ok && val != "" {
 		if passd, ok := resolvedEnv[val]; ok {
-			meta.password = passd
+			info.password = passd
 		}
 	}
 
-	meta.databaseIndex = defaultDbIdx
+	info.databaseIndex = defaultDbIdx
 	if val, ok := metadata["databaseIndex"]; ok {
 		dbIndex, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("databaseIndex: parsing error %s", err.Error())
+			return info, fmt.Errorf("databaseIndex: parsing error %s", err.Error())
+		}
+		info.databaseIndex = int(dbIndex)
+		if info.connectionType == redisConnectionTypeCluster && info.databaseIndex != 0 {
+			return info, fmt.Errorf("databaseIndex is not supported by Redis Cluster")
 		}
-		meta.databaseIndex = int(dbIndex)
 	}
 
-	meta.enableTLS = defaultEnableTLS
+	info.enableTLS = defaultEnableTLS
 	if val, ok := metadata["enableTLS"]; ok {
 		tls, err := strconv.ParseBool(val)
 		if err != nil {
-			return nil, fmt.Errorf("enableTLS parsing error %s", err.Error())
+			return info, fmt.Errorf("enableTLS parsing error %s", err.Error())
 		}
-		meta.enableTLS = tls
+		info.enableTLS = tls
 	}
 
-	return &meta, nil
+	return info, nil
 }
 
 // IsActive checks if there is any element in the Redis list
 func (s *redisScaler) IsActive(ctx context.Context) (bool, error) {
 
-	length, err := getRedisListLength(
-		ctx, s.metadata.address, s.metadata.password, s.metadata.listName, s.metadata.databaseIndex, s.metadata.enableTLS)
+	length, err := getRedisListLength(ctx, s.metadata.connectionInfo, s.metadata.listName)
 
 	if err != nil {
 		redisLog.Error(err, "error")
@@ -130,7 +201,7 @@ func (s *redisScaler) Close() error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *redisScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
-	targetListLengthQty := resource.NewQuantity(int64(s.metadata.targetListLength), resource.DecimalSI)
+	targetListLengthQty := resource.NewMilliQuantity(int64(s.metadata.targetListLength*1000), resource.DecimalSI)
 	externalMetric := &v2beta1.ExternalMetricSource{MetricName: listLengthMetricName, TargetAverageValue: targetListLengthQty}
 	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta1.MetricSpec{metricSpec}
@@ -138,7 +209,7 @@ func (s *redisScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 
 // GetMetrics connects to Redis and finds the length of the list
 func (s *redisScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	listLen, err := getRedisListLength(ctx, s.metadata.address, s.metadata.password, s.metadata.listName, s.metadata.databaseIndex, s.metadata.enableTLS)
+	listLen, err := getRedisListLength(ctx, s.metadata.connectionInfo, s.metadata.listName)
 
 	if err != nil {
 		redisLog.Error(err, "error getting list length")
@@ -147,27 +218,16 @@ func (s *redisScaler) GetMetrics(ctx context.Context, metricName string, metricS
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(listLen, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(listLen*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-func getRedisListLength(ctx context.Context, address string, password string, listName string, dbIndex int, enableTLS bool) (int64, error) {
-	options := &redis.Options{
-		Addr:     address,
-		Password: password,
-		DB:       dbIndex,
-	}
-
-	if enableTLS == true {
-		options.TLSConfig = &tls.Config{
-			InsecureSkipVerify: enableTLS,
-		}
-	}
-
-	client := redis.NewClient(options)
+func getRedisListLength(ctx context.Context, info redisConnectionInfo, listName string) (int64, error) {
+	client := getRedisClient(info)
+	defer client.Close()
 
 	cmd := client.LLen(listName)
 
@@ -176,3 +236,37 @@ func getRedisListLength(ctx context.Context, address string, password string, li
 	}
 	return cmd.Result()
 }
+
+// getRedisClient returns a client connected to the single node, Cluster or Sentinel described by the connection info
+func getRedisClient(info redisConnectionInfo) redis.UniversalClient {
+	var tlsConfig *tls.Config
+	if info.enableTLS == true {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: info.enableTLS,
+		}
+	}
+
+	switch info.connectionType {
+	case redisConnectionTypeCluster:
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     info.addresses,
+			Password:  info.password,
+			TLSConfig: tlsConfig,
+		})
+	case redisConnectionTypeSentinel:
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    info.sentinelMaster,
+			SentinelAddrs: info.addresses,
+			Password:      info.password,
+			DB:            info.databaseIndex,
+			TLSConfig:     tlsConfig,
+		})
+	default:
+		return redis.NewClient(&redis.Options{
+			Addr:      info.address,
+			Password:  info.password,
+			DB:        info.databaseIndex,
+			TLSConfig: tlsConfig,
+		})
+	}
+}
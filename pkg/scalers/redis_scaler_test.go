@@ -6,6 +6,7 @@ import (
 
 var testRedisResolvedEnv = map[string]string{
 	"REDIS_HOST":     "none",
+	"REDIS_HOSTS":    "redis-0:6379, redis-1:6379,redis-2:6379",
 	"REDIS_PASSWORD": "none",
 }
 
@@ -28,6 +29,32 @@ var testRedisMetadata = []parseRedisMetadataTestData{
 	{map[string]string{"listName": "mylist", "listLength": "0", "address": "REDIS_WRONG", "password": ""}, true, map[string]string{}},
 	// password is defined in the authParams
 	{map[string]string{"listName": "mylist", "listLength": "0", "address": "REDIS_WRONG"}, true, map[string]string{"password": ""}},
+	// properly formed cluster addresses
+	{map[string]string{"listName": "mylist", "addresses": "REDIS_HOSTS"}, false, map[string]string{}},
+	// cluster addresses do not resolve
+	{map[string]string{"listName": "mylist", "addresses": "REDIS_WRONG"}, true, map[string]string{}},
+	// databaseIndex is not supported by a cluster
+	{map[string]string{"listName": "mylist", "addresses": "REDIS_HOSTS", "databaseIndex": "1"}, true, map[string]string{}},
+	// properly formed sentinel
+	{map[string]string{"listName": "mylist", "addresses": "REDIS_HOSTS", "sentinelMaster": "mymaster", "databaseIndex": "1"}, false, map[string]string{}},
+	// sentinelMaster without addresses
+	{map[string]string{"listName": "mylist", "address": "REDIS_HOST", "sentinelMaster": "mymaster"}, true, map[string]string{}},
+	// addresses are defined in the authParams
+	{map[string]string{"listName": "mylist"}, false, map[string]string{"addresses": "redis-0:6379,redis-1:6379"}},
+}
+
+type parseRedisConnectionInfoTestData struct {
+	metadata          map[string]string
+	authParams        map[string]string
+	expectedType      redisConnectionType
+	expectedAddresses int
+}
+
+var testRedisConnectionInfo = []parseRedisConnectionInfoTestData{
+	{map[string]string{"address": "REDIS_HOST"}, map[string]string{}, redisConnectionTypeSingle, 0},
+	{map[string]string{"addresses": "REDIS_HOSTS"}, map[string]string{}, redisConnectionTypeCluster, 3},
+	{map[string]string{"addresses": "REDIS_HOSTS", "sentinelMaster": "mymaster"}, map[string]string{}, redisConnectionTypeSentinel, 3},
+	{map[string]string{"addresses": "REDIS_HOSTS"}, map[string]string{"sentinelMaster": "mymaster"}, redisConnectionTypeSentinel, 3},
 }
 
 func TestRedisParseMetadata(t *testing.T) {
@@ -41,3 +68,19 @@ func TestRedisParseMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestRedisParseConnectionInfo(t *testing.T) {
+	for _, testData := range testRedisConnectionInfo {
+		info, err := parseRedisConnectionInfo(testData.metadata, testRedisResolvedEnv, testData.authParams)
+		if err != nil {
+			t.Error("Expected success but got error", err)
+			continue
+		}
+		if info.connectionType != testData.expectedType {
+			t.Errorf("Expected connection type %s but got %s", testData.expectedType, info.connectionType)
+		}
+		if len(info.addresses) != testData.expectedAddresses {
+			t.Errorf("Expected %d addresses but got %d", testData.expectedAddresses, len(info.addresses))
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	pendingEntriesCountMetricName = "RedisStreamPendingEntriesCount"
+	streamLengthMetricName        = "RedisStreamLength"
+	defaultTargetPendingEntries   = 5
+)
+
+type redisStreamsScaleFactor string
+
+const (
+	redisStreamsScaleFactorPendingEntries redisStreamsScaleFactor = "pendingEntriesCount"
+	redisStreamsScaleFactorStreamLength   redisStreamsScaleFactor = "streamLength"
+)
+
+type redisStreamsScaler struct {
+	metadata *redisStreamsMetadata
+}
+
+type redisStreamsMetadata struct {
+	scaleFactor    redisStreamsScaleFactor
+	targetValue    float64
+	streamName     string
+	consumerGroup  string
+	connectionInfo redisConnectionInfo
+}
+
+var redisStreamsLog = logf.Log.WithName("redis_streams_scaler")
+
+// NewRedisStreamsScaler creates a new redisStreamsScaler
+func NewRedisStreamsScaler(resolvedEnv, metadata, authParams map[string]string) (Scaler, error) {
+	meta, err := parseRedisStreamsMetadata(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing redis streams metadata: %s", err)
+	}
+
+	return &redisStreamsScaler{
+		metadata: meta,
+	}, nil
+}
+
+func parseRedisStreamsMetadata(metadata, resolvedEnv, authParams map[string]string) (*redisStreamsMetadata, error) {
+	meta := redisStreamsMetadata{}
+
+	if val, ok := metadata["stream"]; ok && val != "" {
+		meta.streamName = val
+	} else {
+		return nil, fmt.Errorf("no stream name given")
+	}
+
+	if val, ok := metadata["consumerGroup"]; ok {
+		meta.consumerGroup = val
+	}
+
+	pendingEntriesCount, hasPendingEntriesCount := metadata["pendingEntriesCount"]
+	streamLength, hasStreamLength := metadata["streamLength"]
+	if hasPendingEntriesCount && hasStreamLength {
+		return nil, fmt.Errorf("only one of pendingEntriesCount and streamLength can be given")
+	}
+
+	if hasStreamLength {
+		meta.scaleFactor = redisStreamsScaleFactorStreamLength
+		targetValue, err := strconv.ParseFloat(streamLength, 64)
+		if err != nil {
+			return nil, fmt.Errorf("streamLength parsing error %s", err.Error())
+		}
+		meta.targetValue = targetValue
+	} else {
+		meta.scaleFactor = redisStreamsScaleFactorPendingEntries
+		meta.targetValue = defaultTargetPendingEntries
+		if hasPendingEntriesCount {
+			targetValue, err := strconv.ParseFloat(pendingEntriesCount, 64)
+			if err != nil {
+				return nil, fmt.Errorf("pendingEntriesCount parsing error %s", err.Error())
+			}
+			meta.targetValue = targetValue
+		}
+		if meta.consumerGroup == "" {
+			return nil, fmt.Errorf("no consumer group given, consumerGroup is required for pendingEntriesCount")
+		}
+	}
+
+	connectionInfo, err := parseRedisConnectionInfo(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return nil, err
+	}
+	meta.connectionInfo = connectionInfo
+
+	return &meta, nil
+}
+
+// IsActive checks if there are pending entries in the consumer group or entries in the stream
+func (s *redisStreamsScaler) IsActive(ctx context.Context) (bool, error) {
+	count, err := s.getStreamCount()
+
+	if err != nil {
+		redisStreamsLog.Error(err, "error")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *redisStreamsScaler) Close() error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *redisStreamsScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
+	metricName := pendingEntriesCountMetricName
+	if s.metadata.scaleFactor == redisStreamsScaleFactorStreamLength {
+		metricName = streamLengthMetricName
+	}
+
+	targetValueQty := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
+	externalMetric := &v2beta1.ExternalMetricSource{MetricName: metricName, TargetAverageValue: targetValueQty}
+	metricSpec := v2beta1.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2beta1.MetricSpec{metricSpec}
+}
+
+// GetMetrics connects to Redis and finds the number of pending entries or the length of the stream
+func (s *redisStreamsScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	count, err := s.getStreamCount()
+
+	if err != nil {
+		redisStreamsLog.Error(err, "error getting stream count")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(count*1000, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getStreamCount returns the XPENDING count of the consumer group or the XLEN of the stream, depending on the scale factor
+func (s *redisStreamsScaler) getStreamCount() (int64, error) {
+	client := getRedisClient(s.metadata.connectionInfo)
+	defer client.Close()
+
+	if s.metadata.scaleFactor == redisStreamsScaleFactorStreamLength {
+		return client.XLen(s.metadata.streamName).Result()
+	}
+
+	pending, err := client.XPending(s.metadata.streamName, s.metadata.consumerGroup).Result()
+	if err != nil {
+		return -1, err
+	}
+	return pending.Count, nil
+}
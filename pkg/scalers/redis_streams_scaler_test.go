@@ -0,0 +1,54 @@
+package scalers
+
+import (
+	"testing"
+)
+
+type parseRedisStreamsMetadataTestData struct {
+	metadata            map[string]string
+	isError             bool
+	authParams          map[string]string
+	expectedScaleFactor redisStreamsScaleFactor
+	expectedTargetValue float64
+}
+
+var testRedisStreamsMetadata = []parseRedisStreamsMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true, map[string]string{}, "", 0},
+	// properly formed pendingEntriesCount
+	{map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "pendingEntriesCount": "10", "address": "REDIS_HOST", "password": "REDIS_PASSWORD"}, false, map[string]string{}, redisStreamsScaleFactorPendingEntries, 10},
+	// default pendingEntriesCount
+	{map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "address": "REDIS_HOST"}, false, map[string]string{}, redisStreamsScaleFactorPendingEntries, defaultTargetPendingEntries},
+	// missing consumerGroup
+	{map[string]string{"stream": "my-stream", "pendingEntriesCount": "10", "address": "REDIS_HOST"}, true, map[string]string{}, "", 0},
+	// improperly formed pendingEntriesCount
+	{map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "pendingEntriesCount": "AA", "address": "REDIS_HOST"}, true, map[string]string{}, "", 0},
+	// properly formed streamLength, consumerGroup is optional
+	{map[string]string{"stream": "my-stream", "streamLength": "100", "address": "REDIS_HOST"}, false, map[string]string{}, redisStreamsScaleFactorStreamLength, 100},
+	// both pendingEntriesCount and streamLength
+	{map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "pendingEntriesCount": "10", "streamLength": "100", "address": "REDIS_HOST"}, true, map[string]string{}, "", 0},
+	// address does not resolve
+	{map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "address": "REDIS_WRONG"}, true, map[string]string{}, "", 0},
+	// password is defined in the authParams
+	{map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "address": "REDIS_HOST"}, false, map[string]string{"password": "secret"}, redisStreamsScaleFactorPendingEntries, defaultTargetPendingEntries},
+}
+
+func TestRedisStreamsParseMetadata(t *testing.T) {
+	for _, testData := range testRedisStreamsMetadata {
+		meta, err := parseRedisStreamsMetadata(testData.metadata, testRedisResolvedEnv, testData.authParams)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if err == nil {
+			if meta.scaleFactor != testData.expectedScaleFactor {
+				t.Errorf("Expected scale factor %s but got %s", testData.expectedScaleFactor, meta.scaleFactor)
+			}
+			if meta.targetValue != testData.expectedTargetValue {
+				t.Errorf("Expected target value %v but got %v", testData.expectedTargetValue, meta.targetValue)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	sqlQueryTimeout          = "queryTimeout"
+	defaultSQLQueryTimeout   = 10000
+	sqlConnectionMaxLifeTime = 5 * time.Minute
+	sqlConnectionIdleTimeout = 10 * time.Minute
+)
+
+// sqlConnectionPool keeps one sql.DB per driver and DSN, so scalers with the same
+// connection settings share their pooled connections instead of opening new ones.
+// Scalers hand their connection back with release, a connection no scaler used for sqlConnectionIdleTimeout
+// is closed, eg. after a password rotation or when its ScaledObject is deleted.
+type sqlConnectionPool struct {
+	mutex       sync.Mutex
+	connections map[string]*sqlConnection
+}
+
+type sqlConnection struct {
+	db  *sql.DB
+	err error
+	// ready is closed once the connection is opened and pinged, db and err are set by then
+	ready    chan struct{}
+	refs     int
+	lastUsed time.Time
+}
+
+var sqlConnections = &sqlConnectionPool{connections: map[string]*sqlConnection{}}
+
+// get returns the pooled sql.DB for the driver and DSN, opening and pinging it on first use.
+// The connection is opened outside of the pool lock, an unreachable database only blocks the scalers using it.
+func (p *sqlConnectionPool) get(driverName, dsn string) (*sql.DB, error) {
+	key := driverName + "|" + dsn
+
+	p.mutex.Lock()
+	p.closeIdle(time.Now())
+	conn, ok := p.connections[key]
+	if !ok {
+		conn = &sqlConnection{ready: make(chan struct{})}
+		p.connections[key] = conn
+	}
+	conn.refs++
+	p.mutex.Unlock()
+
+	if ok {
+		<-conn.ready
+	} else {
+		conn.db, conn.err = openSQLConnection(driverName, dsn)
+		close(conn.ready)
+	}
+
+	if conn.err != nil {
+		// the failed connection is dropped, the next scaler tries again
+		p.mutex.Lock()
+		conn.refs--
+		if p.connections[key] == conn {
+			delete(p.connections, key)
+		}
+		p.mutex.Unlock()
+		return nil, conn.err
+	}
+
+	return conn.db, nil
+}
+
+// release hands back a connection returned by get
+func (p *sqlConnectionPool) release(db *sql.DB) {
+	if db == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, conn := range p.connections {
+		select {
+		case <-conn.ready:
+		default:
+			// still opening, db isn't set yet
+			continue
+		}
+		if conn.db == db {
+			conn.refs--
+			conn.lastUsed = time.Now()
+			return
+		}
+	}
+}
+
+// closeIdle closes the connections not used by any scaler for sqlConnectionIdleTimeout, the pool lock must be held
+func (p *sqlConnectionPool) closeIdle(now time.Time) {
+	for key, conn := range p.connections {
+		if conn.refs == 0 && now.Sub(conn.lastUsed) > sqlConnectionIdleTimeout {
+			conn.db.Close()
+			delete(p.connections, key)
+		}
+	}
+}
+
+func openSQLConnection(driverName, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening connection: %s", err)
+	}
+	db.SetConnMaxLifetime(sqlConnectionMaxLifeTime)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error pinging database: %s", err)
+	}
+
+	return db, nil
+}
+
+// parseSQLQueryTimeout parses the queryTimeout in milliseconds
+func parseSQLQueryTimeout(metadata map[string]string) (time.Duration, error) {
+	timeout := defaultSQLQueryTimeout
+	if val, ok := metadata[sqlQueryTimeout]; ok && val != "" {
+		t, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, fmt.Errorf("%s parsing error %s", sqlQueryTimeout, err.Error())
+		}
+		if t <= 0 {
+			return 0, fmt.Errorf("%s must be greater than 0", sqlQueryTimeout)
+		}
+		timeout = t
+	}
+	return time.Duration(timeout) * time.Millisecond, nil
+}
+
+// getSQLQueryResult runs the query under the timeout and returns the first column of the first row.
+// Integer and float results are returned as is, NULL and no rows are returned as 0.
+func getSQLQueryResult(ctx context.Context, db *sql.DB, query string, timeout time.Duration) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var value sql.NullFloat64
+	err := db.QueryRowContext(ctx, query).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !value.Valid {
+		return 0, nil
+	}
+	return value.Float64, nil
+}
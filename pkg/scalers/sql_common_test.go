@@ -0,0 +1,228 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSQLDriver answers queries without a database, the query selects the returned row:
+// "int", "float", "null", "empty" for no rows, "sleep" blocks until the context is done.
+// Connecting to the "unreachable" DSN blocks until testSQLUnreachable is closed and fails.
+type testSQLDriver struct{}
+
+var (
+	testSQLUnreachable          = make(chan struct{})
+	testSQLUnreachableCloseOnce sync.Once
+)
+
+type testSQLConn struct{}
+
+type testSQLRows struct {
+	values []driver.Value
+}
+
+func init() {
+	sql.Register("keda-test", testSQLDriver{})
+}
+
+func (testSQLDriver) Open(name string) (driver.Conn, error) {
+	if name == "unreachable" {
+		<-testSQLUnreachable
+		return nil, fmt.Errorf("host unreachable")
+	}
+	return testSQLConn{}, nil
+}
+
+func (testSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+func (testSQLConn) Close() error { return nil }
+func (testSQLConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+func (testSQLConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	switch query {
+	case "int":
+		return &testSQLRows{values: []driver.Value{int64(7)}}, nil
+	case "float":
+		return &testSQLRows{values: []driver.Value{0.75}}, nil
+	case "null":
+		return &testSQLRows{values: []driver.Value{nil}}, nil
+	case "empty":
+		return &testSQLRows{}, nil
+	case "sleep":
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("unknown query %s", query)
+}
+
+func (r *testSQLRows) Columns() []string { return []string{"value"} }
+func (r *testSQLRows) Close() error      { return nil }
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
+
+type sqlQueryResultTestData struct {
+	query         string
+	isError       bool
+	expectedValue float64
+}
+
+var testSQLQueryResults = []sqlQueryResultTestData{
+	{"int", false, 7},
+	{"float", false, 0.75},
+	{"null", false, 0},
+	{"empty", false, 0},
+	{"sleep", true, 0},
+	{"unknown", true, 0},
+}
+
+func TestGetSQLQueryResult(t *testing.T) {
+	db, err := sqlConnections.get("keda-test", "results")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	for _, testData := range testSQLQueryResults {
+		value, err := getSQLQueryResult(context.TODO(), db, testData.query, 50*time.Millisecond)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.query, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.query)
+		}
+		if value != testData.expectedValue {
+			t.Errorf("%s: expected value %f but got %f", testData.query, testData.expectedValue, value)
+		}
+	}
+}
+
+func TestSQLConnectionPool(t *testing.T) {
+	first, err := sqlConnections.get("keda-test", "dsn-1")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	second, err := sqlConnections.get("keda-test", "dsn-1")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	other, err := sqlConnections.get("keda-test", "dsn-2")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	if first != second {
+		t.Error("Expected the same connection for the same DSN")
+	}
+	if first == other {
+		t.Error("Expected different connections for different DSNs")
+	}
+
+	sqlConnections.release(first)
+	sqlConnections.release(second)
+	sqlConnections.release(other)
+}
+
+func TestSQLConnectionPoolClosesIdleConnections(t *testing.T) {
+	idle, err := sqlConnections.get("keda-test", "idle")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	inUse, err := sqlConnections.get("keda-test", "in-use")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	sqlConnections.release(idle)
+
+	sqlConnections.mutex.Lock()
+	sqlConnections.closeIdle(time.Now().Add(sqlConnectionIdleTimeout + time.Second))
+	sqlConnections.mutex.Unlock()
+
+	if err := idle.Ping(); err == nil {
+		t.Error("Expected the idle connection to be closed")
+	}
+	if err := inUse.Ping(); err != nil {
+		t.Error("Expected the connection in use to stay open but got error", err)
+	}
+
+	reopened, err := sqlConnections.get("keda-test", "idle")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if reopened == idle {
+		t.Error("Expected a new connection once the idle one was closed")
+	}
+
+	sqlConnections.release(reopened)
+	sqlConnections.release(inUse)
+}
+
+func TestSQLConnectionPoolDoesNotWaitForUnreachableDatabase(t *testing.T) {
+	unreachable := make(chan error)
+	go func() {
+		_, err := sqlConnections.get("keda-test", "unreachable")
+		unreachable <- err
+	}()
+
+	reachable := make(chan error)
+	go func() {
+		db, err := sqlConnections.get("keda-test", "reachable")
+		sqlConnections.release(db)
+		reachable <- err
+	}()
+
+	select {
+	case err := <-reachable:
+		if err != nil {
+			t.Error("Expected success but got error", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the reachable database not to wait for the unreachable one")
+	}
+
+	testSQLUnreachableCloseOnce.Do(func() { close(testSQLUnreachable) })
+	if err := <-unreachable; err == nil {
+		t.Error("Expected error for the unreachable database but got success")
+	}
+}
+
+type sqlQueryTimeoutTestData struct {
+	metadata        map[string]string
+	isError         bool
+	expectedTimeout time.Duration
+}
+
+var testSQLQueryTimeouts = []sqlQueryTimeoutTestData{
+	{map[string]string{}, false, defaultSQLQueryTimeout * time.Millisecond},
+	{map[string]string{"queryTimeout": "500"}, false, 500 * time.Millisecond},
+	{map[string]string{"queryTimeout": "0"}, true, 0},
+	{map[string]string{"queryTimeout": "1s"}, true, 0},
+}
+
+func TestParseSQLQueryTimeout(t *testing.T) {
+	for _, testData := range testSQLQueryTimeouts {
+		timeout, err := parseSQLQueryTimeout(testData.metadata)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+		if timeout != testData.expectedTimeout {
+			t.Errorf("Expected timeout %s but got %s", testData.expectedTimeout, timeout)
+		}
+	}
+}
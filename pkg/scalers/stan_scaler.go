@@ -46,7 +46,7 @@ type stanMetadata struct {
 	queueGroup                   string
 	durableName                  string
 	subject                      string
-	lagThreshold                 int64
+	lagThreshold                 float64
 }
 
 const (
@@ -96,7 +96,7 @@ func parseStanMetadata(metadata map[string]string) (stanMetadata, error) {
 	meta.lagThreshold = defaultStanLagThreshold
 
 	if val, ok := metadata[lagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
+		t, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return meta, fmt.Errorf("error parsing %s: %s", lagThresholdMetricName, err)
 		}
@@ -184,7 +184,7 @@ func (s *stanScaler) GetMetricSpecForScaling() []v2beta1.MetricSpec {
 		{
 			External: &v2beta1.ExternalMetricSource{
 				MetricName:         lagThresholdMetricName,
-				TargetAverageValue: resource.NewQuantity(s.metadata.lagThreshold, resource.DecimalSI),
+				TargetAverageValue: resource.NewMilliQuantity(int64(s.metadata.lagThreshold*1000), resource.DecimalSI),
 			},
 			Type: stanMetricType,
 		},
@@ -206,7 +206,7 @@ func (s *stanScaler) GetMetrics(ctx context.Context, metricName string, metricSe
 	stanLog.V(1).Info("Stan scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.lagThreshold)
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(totalLag), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(totalLag)*1000, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -0,0 +1,30 @@
+package scalers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// newTLSConfig returns the TLS config for the given client certificate and CA, both are optional
+func newTLSConfig(cert, key, ca string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if cert != "" || key != "" {
+		certificate, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("error parse X509KeyPair: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if ca != "" {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("error parsing ca: no PEM encoded certificate found")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+)
+
+// GetWatchNamespaces returns the namespaces the operator should be watching for changes.
+// WATCH_NAMESPACE can hold a single namespace or a comma-separated list of namespaces,
+// an empty list means that all namespaces are watched.
+func GetWatchNamespaces() ([]string, error) {
+	watchNamespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return ParseWatchNamespaces(watchNamespace), nil
+}
+
+// ParseWatchNamespaces splits a comma-separated list of namespaces, empty entries are ignored
+func ParseWatchNamespaces(watchNamespace string) []string {
+	namespaces := []string{}
+	for _, namespace := range strings.Split(watchNamespace, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+type parseWatchNamespacesTestData struct {
+	watchNamespace string
+	expected       []string
+}
+
+var parseWatchNamespacesTestDataset = []parseWatchNamespacesTestData{
+	// all namespaces
+	{"", []string{}},
+	// single namespace
+	{"keda", []string{"keda"}},
+	// multiple namespaces
+	{"tenant-a,tenant-b", []string{"tenant-a", "tenant-b"}},
+	// spaces and empty entries are ignored
+	{" tenant-a , ,tenant-b,", []string{"tenant-a", "tenant-b"}},
+}
+
+func TestParseWatchNamespaces(t *testing.T) {
+	for _, testData := range parseWatchNamespacesTestDataset {
+		namespaces := ParseWatchNamespaces(testData.watchNamespace)
+		if !reflect.DeepEqual(namespaces, testData.expected) {
+			t.Errorf("Expected %v for WATCH_NAMESPACE '%s' but got %v", testData.expected, testData.watchNamespace, namespaces)
+		}
+	}
+}